@@ -1,10 +1,120 @@
 package api
 
+import (
+	"errors"
+	"math/big"
+	"time"
+)
+
 // ForFunc 迭代函数
 // i表示索引; k v表示迭代值, 如果循环的是list则k为nil;
 // key val为返回值,若希望结果集为List则将key返回为nil,若希望为map则key val都不为空;若key val同时为nil则不将结果加入结果集
 type ForFunc func(i int, k, v interface{}) (key, val interface{})
 
+// ForCtrlFunc 可控制迭代流程的迭代函数,i k v及key val的含义同ForFunc
+// ctrl为nil或ForContinue时继续迭代;ForBreak时结束迭代,本次返回的key val仍加入结果集;其他error时结束迭代并通过ItfErr返回
+type ForCtrlFunc func(i int, k, v interface{}) (key, val interface{}, ctrl error)
+
+var (
+	ForContinue = errors.New("for continue") // 继续迭代
+	ForBreak    = errors.New("for break")    // 结束迭代
+)
+
+// PredFunc 判断函数,i为索引,v为list中的元素
+type PredFunc func(i int, v interface{}) bool
+
+// JoinKind Join的类型
+type JoinKind int
+
+const (
+	InnerJoin JoinKind = 0 // 只保留左右两边都能匹配上的记录
+	LeftJoin  JoinKind = 1 // 保留左边所有记录,未匹配上时不合并右边的记录
+)
+
+// JoinOpt Join的配置,零值即为默认配置
+type JoinOpt struct {
+	NestKey string // 非空时右边匹配到的记录整体放在结果的NestKey下(LeftJoin未匹配时为nil),为空时将右边记录的key合并到结果中
+}
+
+// WalkNode Walk遍历到的节点
+type WalkNode struct {
+	Path   []interface{} // 从Walk的起始节点到当前节点的key(map)或索引(list)序列,起始节点为空
+	Val    interface{}   // 当前节点的值,DescendJsonStr时json字符串为反序列化后的值
+	Depth  int           // 起始节点为0
+	Parent interface{}   // 父节点(map或list),起始节点为nil
+}
+
+// WalkAction Walk回调的返回动作
+type WalkAction int
+
+const (
+	WalkContinue WalkAction = 0 // 继续遍历,当前节点为map/list时进入其子节点
+	WalkSkip     WalkAction = 1 // 不进入当前节点的子节点
+	WalkStop     WalkAction = 2 // 结束遍历
+	WalkReplace  WalkAction = 3 // 用回调返回的newVal替换当前节点的值,且不进入其子节点
+)
+
+// WalkFunc Walk的回调函数,仅action为WalkReplace时newVal有效
+type WalkFunc func(node WalkNode) (newVal interface{}, action WalkAction)
+
+// WalkOpt Walk的配置,零值即为默认配置
+type WalkOpt struct {
+	DescendJsonStr bool // 值为json map/list字符串时,是否反序列化后进入其中;其中有节点被替换时,同SetAllAsMap会将该字符串替换为反序列化后的值
+}
+
+// Iterator list/map的游标,按需逐个访问元素,用法: for it := mapitf.From(v).Iter(); it.Next(); { it.Value().Get("id") }
+type Iterator interface {
+	Next() bool          // 移动到下一个元素,没有更多元素或出错时返回false
+	Index() int          // 当前元素的序号
+	Key() interface{}    // 当前元素的key,list时为nil
+	Value() MapInterface // 当前元素,可继续链式调用
+	RawVal() interface{} // 当前元素的原始值,json str元素不做反序列化
+	Err() error          // 迭代结束后返回迭代中遇到的错误
+}
+
+// UniqKeyFunc UniqByFunc中计算元素去重key的函数,key按数值/深度比较(json.Number("1")与1视为相同)
+type UniqKeyFunc func(i int, v interface{}) (key interface{})
+
+// UniqOpt UniqBy系列的配置,零值即为默认配置
+type UniqOpt struct {
+	KeepLast bool // key重复时保留最后一次出现的元素,默认保留第一次出现的;结果均保持元素原有的相对顺序
+}
+
+// StructOpt ToStruct系列的配置,零值即为默认配置
+type StructOpt struct {
+	// TagName 字段名使用的tag,如:json,mapstructure或自定义tag;
+	// 为空时json str使用json tag解码,map/struct使用mapstructure tag解码;不为空时所有来源均按该tag以mapstructure弱类型解码
+	TagName string
+}
+
+// IterOrder ForEachOrdered迭代map时key的顺序
+type IterOrder int
+
+const (
+	IterOrderNone    IterOrder = 0 // 不保证顺序,同ForEach
+	IterOrderSortKey IterOrder = 1 // 按key升序,key均为数字时按数值比较,否则按字符串比较
+	IterOrderJsonKey IterOrder = 2 // 按key在json原文中的顺序,当前值并非来源于json字符串时同IterOrderSortKey
+)
+
+// KeyLessFunc 自定义map key的顺序,k1需排在k2之前时返回true
+type KeyLessFunc func(k1, k2 interface{}) bool
+
+// FlattenIdxStyle Flatten时list索引在key中的表现形式
+type FlattenIdxStyle int
+
+const (
+	FlattenIdxDot     FlattenIdxStyle = 0 // a.b.0
+	FlattenIdxBracket FlattenIdxStyle = 1 // a.b[0]
+)
+
+// FlattenOpt Flatten/Unflatten的配置,零值即为默认配置
+type FlattenOpt struct {
+	Sep            string          // key的分隔符,默认为"."
+	IdxStyle       FlattenIdxStyle // list索引的表现形式,默认为FlattenIdxDot
+	MaxDepth       int             // 最大展开深度,<=0表示不限制;超过深度的节点作为整体值保留
+	DescendJsonStr bool            // 值为json map/list字符串时,是否将其展开
+}
+
 type MapInterface interface {
 	ToBaseType
 	ToMapType
@@ -12,6 +122,7 @@ type MapInterface interface {
 	ToObjectType
 	OriginTypeChecker
 	SetValType
+	AggregateType
 
 	// Get 获取map中对应类型的值,key必须和map[k]v中k同类型,否则请用GetAny
 	Get(key interface{}) MapInterface
@@ -25,6 +136,59 @@ type MapInterface interface {
 	//Uniq 对list去重
 	Uniq() MapInterface
 
+	// UniqBy 按list中元素path处的值去重,适用于元素为map/struct的list;多层路径时path传[]interface{},如:[]interface{}{"name", "first"}
+	UniqBy(path interface{}, opt ...UniqOpt) MapInterface
+
+	// UniqByFunc 按fn返回的key对list去重
+	UniqByFunc(fn UniqKeyFunc, opt ...UniqOpt) MapInterface
+
+	// UniqDeep 按元素深度相等对list去重,适用于元素为map/list等不可比较类型的list
+	UniqDeep(opt ...UniqOpt) MapInterface
+
+	// Chunk 将list按每组n个元素切分,最后一组可能不足n个,如:[1,2,3] => Chunk(2) => [[1,2],[3]]
+	Chunk(n int) MapInterface
+
+	// Window 滑动窗口,每隔step个元素取size个元素为一组,只保留完整的窗口,如:[1,2,3,4] => Window(3,1) => [[1,2,3],[2,3,4]]
+	Window(size, step int) MapInterface
+
+	// Partition 按pred将list分为两组,返回[[满足pred的元素],[不满足pred的元素]]
+	Partition(pred PredFunc) MapInterface
+
+	// Zip 将当前list与从入口处按otherPath取到的list按索引配对,长度取两者较短的,如:[1,2] zip [a,b,c] => [[1,a],[2,b]]
+	Zip(otherPath ...interface{}) MapInterface
+
+	// Join 当前list与other(list或json list str)按key关联,元素需为map,返回新的[]map[string]interface{},不修改原记录
+	// leftPath,rightPath为多层路径时传[]interface{};key按ToStr后比较,即"42"与42可以关联;一条记录匹配多条时每条匹配生成一条结果
+	// 合并时左右存在相同key以左边为准
+	Join(other interface{}, leftPath, rightPath interface{}, kind JoinKind, opt ...JoinOpt) MapInterface
+
+	// Walk 深度优先遍历当前节点及其所有子节点,map的key按升序遍历;回调可跳过子树,结束遍历或原地替换节点的值
+	Walk(fn WalkFunc, opt ...WalkOpt) MapInterface
+
+	// Union 并集,other为From支持的任意list;元素按EqualKey比较(如"42"与42相同),结果去重并保持元素首次出现的顺序,下同
+	Union(other interface{}) MapInterface
+
+	// Intersect 交集,当前list中同时存在于other中的元素
+	Intersect(other interface{}) MapInterface
+
+	// Difference 差集,当前list中不存在于other中的元素
+	Difference(other interface{}) MapInterface
+
+	// SymmetricDifference 对称差集,只存在于其中一个list中的元素,当前list的元素在前
+	SymmetricDifference(other interface{}) MapInterface
+
+	// Contains 当前list是否包含val,比较方式同Union
+	Contains(val interface{}) (bool, error)
+
+	// CountBy 统计list中各元素(或元素path处的值)出现的次数,返回map,key为pkg.ToStr转换后的值,val为int类型的次数
+	CountBy(path ...interface{}) MapInterface
+
+	// MostCommon 返回出现次数最多的n个值及其次数[[val,count],...],按次数降序,次数相同时按首次出现的顺序;n<=0时返回全部
+	MostCommon(n int, path ...interface{}) MapInterface
+
+	// Flatten 将嵌套的map/list展开为一层的map,如:{"a":{"b":[1,2]}} => {"a.b.0":1,"a.b.1":2},opt不传时使用默认配置
+	Flatten(opt ...FlattenOpt) MapInterface
+
 	// Exist 当前key是否存在,存在则返回对应值+true,不存在返回nil,false.json str中存在也会返回true
 	Exist(key interface{}) (interface{}, bool)
 
@@ -36,6 +200,22 @@ type MapInterface interface {
 	// ForFunc 返回值:若希望结果集为List则将key返回为nil,若希望为map则key val都不为空;若key val同时为nil则不将结果加入结果集
 	ForEach(forFunc ForFunc) MapInterface
 
+	// ParallelForEach 并发版ForEach,workers为并发数(<=0时取CPU核数),结果集的顺序与ForEach一致
+	// Fr传入的ctx被cancel时停止迭代;forFunc中的panic会被recover并以UnrecoverablePanicErr返回
+	ParallelForEach(workers int, forFunc ForFunc) MapInterface
+
+	// Iter 返回当前list/map(含json str)的游标,不会预先生成结果集;map的迭代顺序同ForEach
+	Iter() Iterator
+
+	// ForEachCtrl 可提前结束的ForEach,forFunc返回非ForContinue/ForBreak的error时,以IterCallbackErr记录出错的索引或key
+	ForEachCtrl(forFunc ForCtrlFunc) MapInterface
+
+	// ForEachOrdered 按order指定的key顺序迭代Map,ForFunc的i及List结果集的顺序均与之一致;迭代List时同ForEach
+	ForEachOrdered(order IterOrder, forFunc ForFunc) MapInterface
+
+	// ForEachSorted 按less自定义的key顺序迭代Map,迭代List时同ForEach
+	ForEachSorted(less KeyLessFunc, forFunc ForFunc) MapInterface
+
 	// New 用于分段调用,clone出一个新的当前现场
 	New() MapInterface
 
@@ -47,6 +227,8 @@ type ToBaseType interface {
 	Val() (interface{}, error) // Val 直接返回当前节点的值
 	ToStr() (string, error)
 	ToByte() ([]byte, error)
+	// ToInt..ToFloat64 及ToMapType,ToArrayType中的数值转换默认直接强转,
+	// 开启严格模式(conf.CONF.StrictNumberCvt或conf.WithStrictNumberCvt)后溢出或丢失精度时返回NumberOverflow/NumberPrecisionLoss错误
 	ToInt() (int, error)
 	ToInt64() (int64, error)
 	ToInt32() (int32, error)
@@ -58,6 +240,26 @@ type ToBaseType interface {
 	ToFloat64() (float64, error)
 	// ToBool 如:1转成ture,"true"成true
 	ToBool() (bool, error)
+
+	// ToTime 支持unix时间戳(按数值大小推断秒,毫秒,微秒,纳秒)及时间字符串,layouts为空时依次尝试pkg.DefaultTimeLayouts,时区为time.Local
+	ToTime(layouts ...string) (time.Time, error)
+	// ToTimeIn 同ToTime,使用loc作为时区
+	ToTimeIn(loc *time.Location, layouts ...string) (time.Time, error)
+	// ToDuration 字符串如"1h30m","1d12h","2w",数字按秒处理
+	ToDuration() (time.Duration, error)
+	// ToBytesSize 字节数,如:"512MiB","10GB",KB等为1000进制,KiB等为1024进制;单位无法识别时返回UnitParseFailed错误
+	ToBytesSize() (int64, error)
+	// ToPercent 百分数转为0-1的比例,如:"75%"转为0.75,不带%时视为比例本身
+	ToPercent() (float64, error)
+
+	// ToBigInt 基于json.Number或字符串的原始文本精确转换,如"1.5e3"转为1500,适用于超出int64/float64精度的id
+	ToBigInt() (*big.Int, error)
+	// ToBigFloat 同ToBigInt,精度取max(64,分子分母的位数)
+	ToBigFloat() (*big.Float, error)
+	// ToBigRat 同ToBigInt,精确表示十进制小数
+	ToBigRat() (*big.Rat, error)
+	// ToDecimalStr 转换为保留scale位小数的十进制字符串(0.5远离0舍入),不经过float64,适用于金额
+	ToDecimalStr(scale int) (string, error)
 }
 
 type OriginTypeChecker interface {
@@ -114,10 +316,38 @@ type ToArrayType interface {
 	ToListFloat32() ([]float32, error)
 	ToListFloat64() ([]float64, error)
 	ToListBool() ([]bool, error)
+	ToListTime(layouts ...string) ([]time.Time, error) // 元素的转换规则同ToTime
 }
 
 type ToObjectType interface {
-	ToStruct(out interface{}) (interface{}, error) // ToStruct 支持map,str,[]byte等对象转化为struct
+	// ToStruct 支持map,str,[]byte,其他struct等对象转化为struct,out必须是指针;
+	// map/struct来源的字段转换失败时,可通过errors.As获取*itferr.StructFieldErr,其中包含所有失败字段的路径及原因
+	ToStruct(out interface{}, opt ...StructOpt) (interface{}, error)
+	// ToStructList out为*[]T或*[]*T,list的每个元素按ToStruct的规则转换,错误中包含元素下标及字段路径,失败时参考SkipCvtFailForToArrayType
+	ToStructList(out interface{}, opt ...StructOpt) (interface{}, error)
+	// ToStructMap out为*map[K]T或*map[K]*T,map的每个value按ToStruct的规则转换,失败时参考SkipCvtFailForToMapType
+	ToStructMap(out interface{}, opt ...StructOpt) (interface{}, error)
+	// ToTyped 按ptr指向的类型递归转换并写入ptr,如:*map[string][]string,*map[int64]string,*[][]int,规则见pkg.ConvertTo;
+	// 元素转换失败时参考SkipCvtFailForToArrayType/SkipCvtFailForToMapType,错误中包含失败元素的路径,如:[scores][1]
+	ToTyped(ptr interface{}) error
+}
+
+// AggregateType 对数字型list做聚合计算,元素支持json.Number,数字字符串,int,float等混合类型(经pkg.ToFloat64转换)
+// path不为空时,先对list中的每个元素按path取值(同GetAny)再聚合,如:From(x).Get("items").Sum("price")
+// 元素取值或转换失败时,参考conf.SkipCvtFailForToArrayType决定跳过还是返回错误
+type AggregateType interface {
+	// Sum 求和,空list返回0
+	Sum(path ...interface{}) (float64, error)
+	// Avg 求平均值,空list返回错误
+	Avg(path ...interface{}) (float64, error)
+	Min(path ...interface{}) (float64, error)
+	Max(path ...interface{}) (float64, error)
+	// Count 参与聚合的元素个数
+	Count(path ...interface{}) (int, error)
+	// Percentile 百分位数,p的取值范围为[0,100],非整数位置采用线性插值
+	Percentile(p float64, path ...interface{}) (float64, error)
+	// StdDev 总体标准差
+	StdDev(path ...interface{}) (float64, error)
 }
 
 type SetValType interface {
@@ -1,6 +1,9 @@
 package conf
 
-import "github.com/runingriver/mapinterface/logx"
+import (
+	"context"
+	"github.com/runingriver/mapinterface/logx"
+)
 
 var (
 	CONF *Conf
@@ -13,13 +16,52 @@ type Conf struct {
 	SkipCvtFailForToMapType bool
 	// 参考ToArrayType接口方法,将对象转成[]int类型时,是否忽略转换失败的情况,如:[]int类型时,9个转成功,1个转失败时,是返回含9个元素的list还是返回错误;
 	SkipCvtFailForToArrayType bool
+	// 数值转换是否使用严格模式,如:ToInt32时超出int32范围,ToInt时1.9会丢失小数部分,严格模式下返回NumberOverflow/NumberPrecisionLoss错误而不是静默截断
+	StrictNumberCvt bool
+	// 转换为bool时视为true/false的字符串,大小写不敏感,如:"Y"/"N","yes"/"no","on"/"off"
+	BoolTrueStrs  []string
+	BoolFalseStrs []string
+	// bool转换是否使用严格模式,严格模式下数字只接受0和1,其他数字返回错误;非严格模式下沿用原有规则,取整后为1时为true,其他数字为false
+	StrictBoolCvt bool
+	// ToStr中float32/float64/json.Number转换为string的格式
+	NumberFmt NumberFmt
+	// 数字字符串是否支持0x,0o,0b前缀及数字间的下划线,如:"0x1F","0o17","0b1010","1_000_000",默认:false
+	ParseNumLiteral bool
+	// 数字字符串中的千分位分隔符,如:","时"1,234.50"解析为1234.5;为空(默认)时不支持,小数点固定为'.'
+	ThousandsSep string
+	// 自定义类型转换函数,通过RegisterConverter注册
+	converters converterRegistry
+}
+
+// NumberFmt 数值转换为string的格式,参考strconv.FormatFloat;零值即为默认格式
+type NumberFmt struct {
+	// 'G'(默认,较大或较小的数使用科学计数法,如:1.234567E+06),'f'(定点,如:1234567),'g','e'等,同strconv.FormatFloat
+	FloatFmt byte
+	// 精度,nil(默认)表示能精确还原该数值的最少位数;'f'时为小数位数,如:conf.Prec(2)
+	Prec *int
+	// 是否去掉小数部分末尾的0及小数点,如:'f'且Prec为2时1.50->1.5,2.00->2
+	TrimZero bool
+	// json.Number是否按float64以FloatFmt,Prec格式化,默认false即原样输出,避免大整数(如:id)丢失精度
+	JsonNumberAsFloat bool
+}
+
+// Prec 返回p的指针,用于设置NumberFmt.Prec
+func Prec(p int) *int {
+	return &p
 }
 
+type strictNumberCvtKey struct{}
+
 func init() {
 	CONF = &Conf{
 		CvtStrUseStringMethod:     true,
 		SkipCvtFailForToMapType:   false,
 		SkipCvtFailForToArrayType: false,
+		StrictNumberCvt:           false,
+		BoolTrueStrs:              []string{"true", "t", "yes", "y", "on"},
+		BoolFalseStrs:             []string{"false", "f", "no", "n", "off"},
+		StrictBoolCvt:             false,
+		NumberFmt:                 NumberFmt{},
 	}
 }
 
@@ -45,3 +87,58 @@ func (c *Conf) SetSkipCvtFailForToArrayType(b bool) *Conf {
 	c.SkipCvtFailForToArrayType = b
 	return c
 }
+
+// SetStrictNumberCvt 数值转换是否使用严格模式(溢出或丢失精度时返回错误),默认:false
+func (c *Conf) SetStrictNumberCvt(b bool) *Conf {
+	c.StrictNumberCvt = b
+	return c
+}
+
+// SetBoolStrs 转换为bool时视为true/false的字符串(大小写不敏感),默认:true,t,yes,y,on / false,f,no,n,off
+func (c *Conf) SetBoolStrs(trueStrs, falseStrs []string) *Conf {
+	c.BoolTrueStrs = trueStrs
+	c.BoolFalseStrs = falseStrs
+	return c
+}
+
+// SetStrictBoolCvt bool转换是否使用严格模式(数字只接受0和1),默认:false
+func (c *Conf) SetStrictBoolCvt(b bool) *Conf {
+	c.StrictBoolCvt = b
+	return c
+}
+
+// SetNumberFmt ToStr中float及json.Number的格式,默认:NumberFmt{}即'G',最少位数,json.Number原样输出
+func (c *Conf) SetNumberFmt(f NumberFmt) *Conf {
+	c.NumberFmt = f
+	return c
+}
+
+// SetParseNumLiteral 数字字符串是否支持0x,0o,0b前缀及数字间的下划线,默认:false
+func (c *Conf) SetParseNumLiteral(b bool) *Conf {
+	c.ParseNumLiteral = b
+	return c
+}
+
+// SetThousandsSep 数字字符串中的千分位分隔符,如:",",为空时不支持,默认:""
+func (c *Conf) SetThousandsSep(sep string) *Conf {
+	c.ThousandsSep = sep
+	return c
+}
+
+// WithStrictNumberCvt 单次调用级别的严格模式设置,优先于CONF.StrictNumberCvt,如: mapitf.Fr(conf.WithStrictNumberCvt(ctx, true), m)
+func WithStrictNumberCvt(ctx context.Context, b bool) context.Context {
+	if ctx == nil {
+		ctx = context.TODO()
+	}
+	return context.WithValue(ctx, strictNumberCvtKey{}, b)
+}
+
+// IsStrictNumberCvt ctx中设置了WithStrictNumberCvt时以其为准,否则使用CONF.StrictNumberCvt
+func IsStrictNumberCvt(ctx context.Context) bool {
+	if ctx != nil {
+		if b, ok := ctx.Value(strictNumberCvtKey{}).(bool); ok {
+			return b
+		}
+	}
+	return CONF.StrictNumberCvt
+}
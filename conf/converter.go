@@ -0,0 +1,59 @@
+package conf
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// ConverterFunc 自定义类型转换函数,返回值再按内置规则转换为目标类型,如:注册到reflect.Int64时可返回int64,json.Number或数字字符串
+type ConverterFunc func(v interface{}) (interface{}, error)
+
+type converterKey struct {
+	srcType reflect.Type
+	dstKind reflect.Kind
+}
+
+// converterRegistry Conf中注册的自定义转换函数
+type converterRegistry struct {
+	mu         sync.RWMutex
+	converters map[converterKey]ConverterFunc
+	// cnt 已注册的转换函数个数,未注册时To*方法无需加锁查找
+	cnt int32
+}
+
+// RegisterConverter 注册srcType转换为dstKind类型时使用的转换函数,所有To*方法在内置规则之前优先使用;fn为nil时取消注册
+// 如: mapitf.Config().RegisterConverter(reflect.TypeOf(Money{}), reflect.Int64, func(v interface{}) (interface{}, error) { return v.(Money).Cent, nil })
+func (c *Conf) RegisterConverter(srcType reflect.Type, dstKind reflect.Kind, fn ConverterFunc) *Conf {
+	r := &c.converters
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := converterKey{srcType: srcType, dstKind: dstKind}
+	if fn == nil {
+		delete(r.converters, key)
+	} else {
+		if r.converters == nil {
+			r.converters = make(map[converterKey]ConverterFunc)
+		}
+		r.converters[key] = fn
+	}
+	atomic.StoreInt32(&r.cnt, int32(len(r.converters)))
+	return c
+}
+
+// HasConverters 是否注册了自定义转换函数,未注册时To*方法可直接跳过查找
+func (c *Conf) HasConverters() bool {
+	return atomic.LoadInt32(&c.converters.cnt) != 0
+}
+
+// LookupConverter 查找srcType转换为dstKind类型时注册的转换函数
+func (c *Conf) LookupConverter(srcType reflect.Type, dstKind reflect.Kind) (ConverterFunc, bool) {
+	if !c.HasConverters() {
+		return nil, false
+	}
+	r := &c.converters
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	fn, ok := r.converters[converterKey{srcType: srcType, dstKind: dstKind}]
+	return fn, ok
+}
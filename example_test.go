@@ -2,12 +2,20 @@ package mapinterface
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/runingriver/mapinterface/api"
+	"github.com/runingriver/mapinterface/conf"
+	"github.com/runingriver/mapinterface/itferr"
 	"github.com/runingriver/mapinterface/mapitf"
 	"github.com/runingriver/mapinterface/pkg"
 	"github.com/smartystreets/goconvey/convey"
+	"math/big"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -96,7 +104,8 @@ func TestExceptionUsage(t *testing.T) {
 	appId, err := holder.ToStr()
 
 	assert.Nil(t, err)
-	assert.Equal(t, "app_id", appId)
+	// map的迭代顺序不固定,Index(1)可能是任意一个key
+	assert.Contains(t, []string{"item_id", "app_id"}, appId)
 	assert.Equal(t, "map[string]interface {} => users:[]interface {} => 1:map[string]interface {} => info:map[string]interface {} => 1:[]interface {}", path)
 
 	name, err := mapitf.From(OriginTypeChecker).GetAny("map").Get("name").ToStr()
@@ -941,3 +950,1224 @@ func Test_SetAllAsMap(t *testing.T) {
 	val, _ = mapitf.From(SetAsMap).GetAny("map-itf-list-except", "coupon_list").Val()
 	assert.IsType(t, []string{}, val)
 }
+
+func Test_Aggregate(t *testing.T) {
+	sum, err := mapitf.From(jsonStrList[3]).GetAny("vendor", "items").Sum("price")
+	assert.Nil(t, err)
+	assert.Equal(t, float64(5100), sum)
+
+	avg, err := mapitf.From(jsonStrList[3]).GetAny("vendor", "items").Avg("price")
+	assert.Nil(t, err)
+	assert.Equal(t, float64(1275), avg)
+
+	mixed := []interface{}{json.Number("3"), "1", 4, 1.5, int64(2)}
+	minVal, err := mapitf.From(mixed).Min()
+	assert.Nil(t, err)
+	assert.Equal(t, 1.0, minVal)
+
+	maxVal, err := mapitf.From(mixed).Max()
+	assert.Nil(t, err)
+	assert.Equal(t, 4.0, maxVal)
+
+	cnt, err := mapitf.From(mixed).Count()
+	assert.Nil(t, err)
+	assert.Equal(t, 5, cnt)
+
+	median, err := mapitf.From(mixed).Percentile(50)
+	assert.Nil(t, err)
+	assert.Equal(t, 2.0, median)
+
+	p90, err := mapitf.From("[1,2,3,4]").Percentile(90)
+	assert.Nil(t, err)
+	assert.InDelta(t, 3.7, p90, 1e-9)
+
+	stdDev, err := mapitf.From([]int{2, 4, 4, 4, 5, 5, 7, 9}).StdDev()
+	assert.Nil(t, err)
+	assert.Equal(t, 2.0, stdDev)
+
+	// items[3].id is null
+	_, err = mapitf.From(jsonStrList[3]).GetAny("vendor", "items").Sum("id")
+	assert.NotNil(t, err)
+
+	_, err = mapitf.From([]int{}).Avg()
+	assert.NotNil(t, err)
+
+	_, err = mapitf.From(mixed).Percentile(101)
+	assert.NotNil(t, err)
+}
+
+func Test_Flatten(t *testing.T) {
+	flatMap, err := mapitf.From(`{"a":{"b":[1,2]},"c":"x","d":{}}`).Flatten().ToMapStrToStr()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"a.b.0": "1", "a.b.1": "2", "c": "x", "d": "{}"}, flatMap)
+
+	flatMap, err = mapitf.From(`{"a":{"b":[1,2]}}`).Flatten(api.FlattenOpt{Sep: "/", IdxStyle: api.FlattenIdxBracket}).ToMapStrToStr()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"a/b[0]": "1", "a/b[1]": "2"}, flatMap)
+
+	flatMap, err = mapitf.From(`{"a":{"b":[1,2]}}`).Flatten(api.FlattenOpt{MaxDepth: 1}).ToMapStrToStr()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"a": `{"b":[1,2]}`}, flatMap)
+
+	flatMap, err = mapitf.From(MapInnerJsonStr).Flatten(api.FlattenOpt{DescendJsonStr: true}).ToMapStrToStr()
+	assert.Nil(t, err)
+	assert.Equal(t, "2324", flatMap["users.1.info.app_id"])
+
+	orgVal, err := mapitf.Unflatten(map[string]interface{}{"a.b.0": 1, "a.b.1": 2, "a.c": "x"}).Val()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"a": map[string]interface{}{"b": []interface{}{1, 2}, "c": "x"}}, orgVal)
+
+	orgVal, err = mapitf.Unflatten(map[string]string{"a/b[0]": "1", "a/b[1]": "2"}, api.FlattenOpt{Sep: "/", IdxStyle: api.FlattenIdxBracket}).Val()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"a": map[string]interface{}{"b": []interface{}{"1", "2"}}}, orgVal)
+
+	flatItf, err := mapitf.From(jsonStrList[2]).Flatten().ToMap()
+	assert.Nil(t, err)
+	orgVal, err = mapitf.Unflatten(flatItf).Val()
+	assert.Nil(t, err)
+	origin, _ := pkg.JsonLoadsMap(jsonStrList[2])
+	assert.Equal(t, origin, orgVal)
+
+	_, err = mapitf.Unflatten(map[string]interface{}{"a": 1, "a.b": 2}).Val()
+	assert.NotNil(t, err)
+}
+
+func Test_ParallelForEach(t *testing.T) {
+	ids := make([]interface{}, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		ids = append(ids, json.Number(fmt.Sprintf("%d", i)))
+	}
+	result, err := mapitf.From(ids).ParallelForEach(8, func(i int, k, v interface{}) (key, val interface{}) {
+		intV, _ := pkg.ToInt64(v)
+		if intV%2 == 1 {
+			return nil, nil
+		}
+		return nil, intV * 10
+	}).ToListInt64()
+	assert.Nil(t, err)
+	assert.Len(t, result, 500)
+	assert.Equal(t, int64(0), result[0])
+	assert.Equal(t, int64(9980), result[499])
+
+	toMap, err := mapitf.From(jsonStrList[0]).ParallelForEach(0, func(i int, k, v interface{}) (key, val interface{}) {
+		return k, v
+	}).ToMap()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(toMap))
+
+	_, err = mapitf.From(ids).ParallelForEach(4, func(i int, k, v interface{}) (key, val interface{}) {
+		if i == 10 {
+			panic("mock panic")
+		}
+		return nil, v
+	}).ToList()
+	assert.NotNil(t, err)
+	assert.Equal(t, itferr.UnrecoverablePanicErr, itferr.GetErrCode(err))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err = mapitf.Fr(ctx, ids).ParallelForEach(2, func(i int, k, v interface{}) (key, val interface{}) {
+		if i == 5 {
+			cancel()
+		}
+		return nil, v
+	}).ToList()
+	assert.NotNil(t, err)
+	assert.Equal(t, itferr.IterCanceled, itferr.GetErrCode(err))
+}
+
+func Test_ForEachOrdered(t *testing.T) {
+	keyFunc := func(i int, k, v interface{}) (key, val interface{}) {
+		return nil, fmt.Sprintf("%d:%v", i, k)
+	}
+
+	keys, err := mapitf.From(jsonStrList[3]).Get("vendor").ForEachOrdered(api.IterOrderJsonKey, keyFunc).ToListStr()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"0:name", "1:email", "2:website", "3:items", "4:prices", "5:names"}, keys)
+
+	keys, err = mapitf.From(MapInnerJsonStr).Get("users").Index(0).Get("info").ForEachOrdered(api.IterOrderJsonKey, keyFunc).ToListStr()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"0:7351241250965703962", "1:2329", "2:23.29"}, keys)
+
+	holder := mapitf.From(MapInnerJsonStr).GetAny("users").Index(1).GetAny("info").ForEachOrdered(api.IterOrderJsonKey, func(i int, k, v interface{}) (key, val interface{}) {
+		return nil, k
+	}).Index(1)
+	appId, err := holder.ToStr()
+	assert.Nil(t, err)
+	assert.Equal(t, "app_id", appId)
+	assert.Equal(t, "map[string]interface {} => users:[]interface {} => 1:map[string]interface {} => info:map[string]interface {} => 1:[]interface {}", holder.New().PrintPath())
+
+	keys, err = mapitf.From(itfObj[0]).ForEachOrdered(api.IterOrderSortKey, keyFunc).ToListStr()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"0:10010", "1:10011", "2:10012"}, keys)
+
+	// 非json来源时按key升序
+	keys, err = mapitf.From(itfObj[4]).Get("num").ForEachOrdered(api.IterOrderJsonKey, keyFunc).ToListStr()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"0:1001", "1:1002"}, keys)
+
+	keys, err = mapitf.From(jsonStrList[0]).ForEachSorted(func(k1, k2 interface{}) bool {
+		return pkg.ToStr(k1) > pkg.ToStr(k2)
+	}, keyFunc).ToListStr()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"0:name", "1:age"}, keys)
+
+	keys, err = mapitf.From(jsonStrList[3]).GetAny("vendor", "names").ForEachOrdered(api.IterOrderSortKey, keyFunc).ToListStr()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"0:<nil>", "1:<nil>", "2:<nil>", "3:<nil>", "4:<nil>", "5:<nil>"}, keys)
+
+	// struct按字段名(json tag)排序
+	resp := StructResp{structBase: structBase{ID: 7, Creator: "sys"}, Code: 200, Data: &StructItem{SkuID: 1}}
+	keys, err = mapitf.From(resp).ForEachOrdered(api.IterOrderSortKey, keyFunc).ToListStr()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"0:Creator", "1:code", "2:data", "3:id", "4:items"}, keys)
+	keys, err = mapitf.From(&resp).ForEachSorted(func(k1, k2 interface{}) bool {
+		return pkg.ToStr(k1) > pkg.ToStr(k2)
+	}, keyFunc).ToListStr()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"0:items", "1:id", "2:data", "3:code", "4:Creator"}, keys)
+}
+
+func Test_ForEachCtrl(t *testing.T) {
+	// 找到第一个满足条件的元素后结束迭代
+	visited := 0
+	first, err := mapitf.From(jsonStrList[3]).GetAny("vendor", "items").ForEachCtrl(func(i int, k, v interface{}) (key, val interface{}, ctrl error) {
+		visited++
+		price, _ := mapitf.From(v).Get("price").ToInt64()
+		if price < 1500 {
+			return nil, nil, api.ForContinue
+		}
+		return nil, v, api.ForBreak
+	}).Index(0).Get("name").ToStr()
+	assert.Nil(t, err)
+	assert.Equal(t, "MacBook Pro 15 inch retina", first)
+	assert.Equal(t, 2, visited)
+
+	names, err := mapitf.From(jsonStrList[3]).GetAny("vendor", "names").ForEachCtrl(func(i int, k, v interface{}) (key, val interface{}, ctrl error) {
+		return nil, v, nil
+	}).ToListStr()
+	assert.Nil(t, err)
+	assert.Len(t, names, 6)
+
+	mockErr := errors.New("mock err")
+	_, err = mapitf.From(jsonStrList[3]).GetAny("vendor", "items").ForEachCtrl(func(i int, k, v interface{}) (key, val interface{}, ctrl error) {
+		if _, idErr := mapitf.From(v).Get("id").ToInt64(); idErr != nil {
+			return nil, nil, mockErr
+		}
+		return nil, v, nil
+	}).ToList()
+	assert.NotNil(t, err)
+	assert.Equal(t, itferr.IterCallbackErr, itferr.GetErrCode(err))
+	assert.True(t, errors.Is(err, mockErr))
+	assert.Contains(t, err.Error(), "ForEachCtrl(idx:3)")
+
+	_, err = mapitf.From(jsonStrList[0]).ForEachCtrl(func(i int, k, v interface{}) (key, val interface{}, ctrl error) {
+		return k, v, mockErr
+	}).ToMap()
+	assert.Equal(t, itferr.IterCallbackErr, itferr.GetErrCode(err))
+	assert.Contains(t, err.Error(), "ForEachCtrl(key:")
+}
+
+func Test_UniqBy(t *testing.T) {
+	users := []map[string]interface{}{
+		{"id": json.Number("1"), "name": "Jak"},
+		{"id": 2, "name": "Tom"},
+		{"id": 1, "name": "Kav"},
+		{"id": "2", "name": "Bob"},
+	}
+	result, err := mapitf.From(users).UniqBy("id").ToListMap()
+	assert.Nil(t, err)
+	assert.Equal(t, []map[string]interface{}{users[0], users[1], users[3]}, result)
+
+	result, err = mapitf.From(users).UniqBy("id", api.UniqOpt{KeepLast: true}).ToListMap()
+	assert.Nil(t, err)
+	assert.Equal(t, []map[string]interface{}{users[1], users[2], users[3]}, result)
+
+	uniqUsers, err := mapitf.From(jsonStrList[2]).Get("users").UniqBy([]interface{}{"name", "first"}).ToListMap()
+	assert.Nil(t, err)
+	assert.Len(t, uniqUsers, 2)
+	assert.Equal(t, json.Number("2"), uniqUsers[1]["id"])
+
+	type student struct {
+		Name  string `json:"name"`
+		Grade int
+	}
+	students := []student{{"Jak", 1}, {"Tom", 1}, {"Jak", 2}}
+	uniqStudents, err := mapitf.From(students).UniqBy("name").Val()
+	assert.Nil(t, err)
+	assert.Equal(t, []student{{"Jak", 1}, {"Tom", 1}}, uniqStudents)
+
+	uniqStudents, err = mapitf.From(students).UniqByFunc(func(i int, v interface{}) interface{} {
+		return v.(student).Grade
+	}, api.UniqOpt{KeepLast: true}).Val()
+	assert.Nil(t, err)
+	assert.Equal(t, []student{{"Tom", 1}, {"Jak", 2}}, uniqStudents)
+
+	_, err = mapitf.From(users).UniqBy("age").Val()
+	assert.NotNil(t, err)
+
+	deep, err := mapitf.From([]interface{}{
+		map[string]interface{}{"a": json.Number("1"), "b": []interface{}{1, "x"}},
+		map[string]interface{}{"b": []interface{}{json.Number("1.0"), "x"}, "a": 1},
+		map[string]interface{}{"a": "1"},
+		json.Number("1"), 1, int8(1),
+	}).UniqDeep().ToList()
+	assert.Nil(t, err)
+	assert.Len(t, deep, 3)
+}
+
+func Test_ListOperation(t *testing.T) {
+	ids := []int64{1, 2, 3, 4, 5}
+	chunks, err := mapitf.From(ids).Chunk(2).ToList()
+	assert.Nil(t, err)
+	assert.Len(t, chunks, 3)
+	lastChunk, err := mapitf.From(ids).Chunk(2).Index(2).ToListInt64()
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{5}, lastChunk)
+
+	windows, err := mapitf.From("[1,2,3,4]").Window(3, 1).ToList()
+	assert.Nil(t, err)
+	assert.Len(t, windows, 2)
+	window, err := mapitf.From("[1,2,3,4]").Window(3, 1).Index(1).ToListInt()
+	assert.Nil(t, err)
+	assert.Equal(t, []int{2, 3, 4}, window)
+
+	_, err = mapitf.From(ids).Chunk(0).ToList()
+	assert.NotNil(t, err)
+	emptyChunks, err := mapitf.From([]int64{}).Chunk(2).ToListMap()
+	assert.Nil(t, err)
+	assert.Empty(t, emptyChunks)
+
+	expensive := func(i int, v interface{}) bool {
+		price, _ := mapitf.From(v).Get("price").ToInt64()
+		return price >= 1350
+	}
+	matches, err := mapitf.From(jsonStrList[3]).GetAny("vendor", "items").Partition(expensive).Index(0).ToListMap()
+	assert.Nil(t, err)
+	assert.Len(t, matches, 2)
+	others, err := mapitf.From(jsonStrList[3]).GetAny("vendor", "items").Partition(expensive).Index(1).ToListMap()
+	assert.Nil(t, err)
+	assert.Len(t, others, 2)
+
+	pair, err := mapitf.From(jsonStrList[3]).GetAny("vendor", "names").Zip("vendor", "prices").Index(3).ToListStr()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Jerry", "400.87"}, pair)
+
+	pairs, err := mapitf.From(itfObj[1]).Zip(1).ToList()
+	assert.NotNil(t, err)
+	assert.Nil(t, pairs)
+}
+
+func Test_Join(t *testing.T) {
+	orders := `[{"order_id":1,"user_id":42},{"order_id":2,"user_id":"7"},{"order_id":3,"user_id":42},{"order_id":4}]`
+	users := []map[string]interface{}{
+		{"user_id": "42", "name": "Jak"},
+		{"user_id": json.Number("7"), "name": "Tom"},
+		{"user_id": 9, "name": "Kav"},
+	}
+
+	rows, err := mapitf.From(orders).Join(users, "user_id", "user_id", api.InnerJoin).ToListMap()
+	assert.Nil(t, err)
+	assert.Len(t, rows, 3)
+	assert.Equal(t, "Jak", rows[0]["name"])
+	assert.Equal(t, json.Number("42"), rows[0]["user_id"])
+	assert.Equal(t, "Tom", rows[1]["name"])
+	_, merged := users[0]["order_id"]
+	assert.False(t, merged)
+
+	rows, err = mapitf.From(orders).Join(users, "user_id", "user_id", api.LeftJoin, api.JoinOpt{NestKey: "user"}).ToListMap()
+	assert.Nil(t, err)
+	assert.Len(t, rows, 4)
+	assert.Equal(t, users[1], rows[1]["user"])
+	assert.Nil(t, rows[3]["user"])
+
+	name, err := mapitf.From(orders).Join(`[{"info":{"uid":7,"name":"Tom"}}]`, "user_id", []interface{}{"info", "uid"}, api.InnerJoin, api.JoinOpt{NestKey: "user"}).
+		Index(0).GetAny("user", "info", "name").ToStr()
+	assert.Nil(t, err)
+	assert.Equal(t, "Tom", name)
+
+	noMatch, err := mapitf.From(orders).Join(`[{"user_id":100}]`, "user_id", "user_id", api.InnerJoin).ToListMap()
+	assert.Nil(t, err)
+	assert.Empty(t, noMatch)
+
+	_, err = mapitf.From(orders).Join([]int{1, 2}, "user_id", "user_id", api.InnerJoin).ToList()
+	assert.NotNil(t, err)
+}
+
+func Test_Walk(t *testing.T) {
+	paths := make([]string, 0)
+	_, err := mapitf.From(jsonStrList[0]).Walk(func(node api.WalkNode) (interface{}, api.WalkAction) {
+		paths = append(paths, fmt.Sprintf("%d:%v", node.Depth, node.Path))
+		return nil, api.WalkContinue
+	}).Val()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"0:[]", "1:[age]", "1:[name]", "2:[name first]", "2:[name last]"}, paths)
+
+	// 跳过子树,替换值
+	data, _ := pkg.JsonLoadsMap(jsonStrList[3])
+	_, err = mapitf.From(data).Walk(func(node api.WalkNode) (interface{}, api.WalkAction) {
+		if len(node.Path) > 0 && node.Path[len(node.Path)-1] == "prices" {
+			return nil, api.WalkSkip
+		}
+		if price, ok := node.Val.(json.Number); ok && node.Path[len(node.Path)-1] == "price" {
+			p, _ := price.Float64()
+			return p * 2, api.WalkReplace
+		}
+		return nil, api.WalkContinue
+	}).Val()
+	assert.Nil(t, err)
+	price, err := mapitf.From(data).GetAny("vendor", "items").Index(1).Get("price").Val()
+	assert.Nil(t, err)
+	assert.Equal(t, 3400.0, price)
+	prices, err := mapitf.From(data).GetAny("vendor", "prices").Index(0).Val()
+	assert.Nil(t, err)
+	assert.Equal(t, json.Number("2400"), prices)
+
+	// 结束遍历
+	visited := 0
+	_, err = mapitf.From(jsonStrList[3]).GetAny("vendor", "names").Walk(func(node api.WalkNode) (interface{}, api.WalkAction) {
+		visited++
+		if node.Val == "Tom" {
+			return nil, api.WalkStop
+		}
+		return nil, api.WalkContinue
+	}).Val()
+	assert.Nil(t, err)
+	assert.Equal(t, 4, visited)
+
+	// 进入json字符串并替换
+	mapInnerJson, _ := pkg.JsonLoadsMap(MapInnerJsonStr)
+	_, err = mapitf.From(mapInnerJson).Walk(func(node api.WalkNode) (interface{}, api.WalkAction) {
+		if node.Val == "app_id" {
+			return "APP_ID", api.WalkReplace
+		}
+		return nil, api.WalkContinue
+	}, api.WalkOpt{DescendJsonStr: true}).Val()
+	assert.Nil(t, err)
+	appId, err := mapitf.From(mapInnerJson).Get("users").Index(0).Get("info").Get("2329").ToStr()
+	assert.Nil(t, err)
+	assert.Equal(t, "APP_ID", appId)
+	isMap, _ := mapitf.From(mapInnerJson).Get("users").Index(0).Get("info").IsMap()
+	assert.True(t, isMap)
+	isStr, _ := mapitf.From(mapInnerJson).Get("users").Index(1).Get("info").IsStr()
+	assert.True(t, isStr)
+
+	_, err = mapitf.From(map[string]int{"a": 1}).Walk(func(node api.WalkNode) (interface{}, api.WalkAction) {
+		if node.Depth == 1 {
+			return "1", api.WalkReplace
+		}
+		return nil, api.WalkContinue
+	}).Val()
+	assert.Equal(t, itferr.SetValueErr, itferr.GetErrCode(err))
+}
+
+func Test_SetOperation(t *testing.T) {
+	perms := []string{"read", "write", "admin", "read"}
+	granted := `["write","read","audit"]`
+
+	union, err := mapitf.From(perms).Union(granted).ToListStr()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"read", "write", "admin", "audit"}, union)
+
+	intersect, err := mapitf.From(perms).Intersect(granted).ToListStr()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"read", "write"}, intersect)
+
+	diff, err := mapitf.From(perms).Difference(granted).ToListStr()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"admin"}, diff)
+
+	symDiff, err := mapitf.From(perms).SymmetricDifference(granted).ToListStr()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"admin", "audit"}, symDiff)
+
+	ids, err := mapitf.From([]interface{}{json.Number("1"), "2", 3}).Intersect([]int64{2, 3, 4}).ToListInt64()
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{2, 3}, ids)
+
+	empty, err := mapitf.From(perms).Intersect([]string{"none"}).ToListStr()
+	assert.Nil(t, err)
+	assert.Empty(t, empty)
+
+	ok, err := mapitf.From(jsonStrList[3]).GetAny("vendor", "prices").Contains(1200)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	ok, err = mapitf.From(jsonStrList[3]).GetAny("vendor", "prices").Contains("89.9")
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	ok, err = mapitf.From(perms).Contains("audit")
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	mixed := []interface{}{`{"a":1}`, map[string]interface{}{"a": 1}}
+	mixedUnion, err := mapitf.From(mixed).Union([]interface{}{map[string]interface{}{"a": json.Number("1")}}).ToList()
+	assert.Nil(t, err)
+	assert.Equal(t, mixed, mixedUnion)
+	ok, err = mapitf.From([]interface{}{map[string]interface{}{"a": 1}}).Contains(`{"a":1}`)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	_, err = mapitf.From(jsonStrList[0]).Contains("age")
+	assert.NotNil(t, err)
+}
+
+func Test_CountBy(t *testing.T) {
+	counter, err := mapitf.From([]interface{}{"a", json.Number("1"), "b", 1, "a", int8(1)}).CountBy().ToMap()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"a": 2, "b": 1, "1": 3}, counter)
+
+	counterStr, err := mapitf.From(jsonStrList[2]).Get("users").CountBy("name", "first").ToMapStrToStr()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"John": "2", "Ethan": "1"}, counterStr)
+
+	mostCommon, err := mapitf.From("[3,1,2,1,3,4,1]").MostCommon(2).ToList()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{[]interface{}{"1", 3}, []interface{}{"3", 2}}, mostCommon)
+
+	top, err := mapitf.From(jsonStrList[2]).Get("users").MostCommon(1, "name", "first").Index(0).Index(0).ToStr()
+	assert.Nil(t, err)
+	assert.Equal(t, "John", top)
+
+	_, err = mapitf.From(jsonStrList[2]).Get("users").CountBy("age").ToMap()
+	assert.NotNil(t, err)
+}
+
+func Test_Iter(t *testing.T) {
+	names := make([]string, 0)
+	it := mapitf.From(jsonStrList[2]).Get("users").Iter()
+	for it.Next() {
+		assert.Nil(t, it.Key())
+		name, err := it.Value().GetAny("name", "first").ToStr()
+		assert.Nil(t, err)
+		names = append(names, fmt.Sprintf("%d:%s", it.Index(), name))
+	}
+	assert.Nil(t, it.Err())
+	assert.Equal(t, []string{"0:John", "1:Ethan", "2:John"}, names)
+
+	sum := int64(0)
+	for it = mapitf.From(ListInnerJsonStr).Get("index").Iter(); it.Next(); {
+		n, err := it.Value().ToInt64()
+		assert.Nil(t, err)
+		sum += n
+		if it.Index() == 1 {
+			break
+		}
+	}
+	assert.Equal(t, int64(3), sum)
+
+	keys := make(map[interface{}]int)
+	for it = mapitf.From(jsonStrList[0]).Iter(); it.Next(); {
+		keys[it.Key()] = it.Index()
+	}
+	assert.Len(t, keys, 2)
+	path := mapitf.From(jsonStrList[0]).Iter()
+	path.Next()
+	assert.Contains(t, path.Value().PrintPath(), "map[string]interface {} => ")
+
+	it = mapitf.From(jsonStrList[0]).Get("age").Iter()
+	assert.False(t, it.Next())
+	assert.NotNil(t, it.Err())
+	assert.False(t, it.Value().Valid())
+}
+
+func Test_Generic(t *testing.T) {
+	age, err := mapitf.As[uint16](mapitf.From(jsonStrList[0]).Get("age"))
+	assert.Nil(t, err)
+	assert.Equal(t, uint16(47), age)
+
+	prices, err := mapitf.GetAs[[]float32](jsonStrList[3], "vendor", "prices")
+	assert.Nil(t, err)
+	assert.Equal(t, []float32{2400, 2100, 1200, 400.87, 89.9, 150.1}, prices)
+
+	scores, err := mapitf.GetAs[map[string][]map[string]int8](itfObj[4], "num")
+	assert.Nil(t, err)
+	assert.Equal(t, int8(88), scores["1002"][1]["geography"])
+	_, err = mapitf.GetAs[map[int]map[string]int8](itfObj[4], "num")
+	assert.NotNil(t, err)
+
+	index, err := mapitf.GetAs[[]*int64](ListInnerJsonStr, "index")
+	assert.Nil(t, err)
+	assert.Len(t, index, 4)
+	assert.Equal(t, int64(7351241250965703962), *index[3])
+
+	type name struct {
+		First string `json:"first"`
+		Last  string `json:"last"`
+	}
+	users, err := mapitf.GetAs[[]struct {
+		Id   int64
+		Name name
+	}](jsonStrList[2], "users")
+	assert.Nil(t, err)
+	assert.Equal(t, "Ethan", users[1].Name.First)
+
+	names, err := mapitf.Collect[string](mapitf.From([]interface{}{"{\"coupon_id\": 1792669145841964}", json.Number("74"), ""}))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"{\"coupon_id\": 1792669145841964}", "74", ""}, names)
+
+	vals, err := mapitf.Collect[int](mapitf.From(CvtMap).Get("IntToIntForItfItf"))
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []int{1, 2, 3}, vals)
+
+	_, err = mapitf.GetAs[[]int](jsonStrList[3], "vendor", "names")
+	assert.NotNil(t, err)
+	mapitf.Config().SetSkipCvtFailForToArrayType(true)
+	ints, err := mapitf.GetAs[[]int]([]interface{}{"1", "a", 3})
+	mapitf.Config().SetSkipCvtFailForToArrayType(false)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 3}, ints)
+}
+
+func Test_ToTime(t *testing.T) {
+	data := map[string]interface{}{
+		"sec":      1700000000,
+		"ms":       json.Number("1700000000123"),
+		"rfc":      "2023-11-14T22:13:20Z",
+		"datetime": "2023-11-14 22:13:20",
+		"day":      "20231114",
+		"cost":     "1h30m",
+		"timeout":  "90",
+		"list":     "[1700000000, \"2023-11-14T22:13:20Z\"]",
+		"bad":      "yesterday",
+	}
+
+	tm, err := mapitf.From(data).Get("sec").ToTime()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1700000000), tm.Unix())
+
+	tm, err = mapitf.From(data).Get("ms").ToTime()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1700000000123), tm.UnixMilli())
+
+	tm, err = mapitf.From(data).Get("rfc").ToTime()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1700000000), tm.Unix())
+
+	tm, err = mapitf.From(data).Get("datetime").ToTimeIn(time.UTC)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1700000000), tm.Unix())
+
+	tm, err = mapitf.From(data).Get("day").ToTimeIn(time.UTC, "20060102")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2023, 11, 14, 0, 0, 0, 0, time.UTC), tm)
+
+	d, err := mapitf.From(data).Get("cost").ToDuration()
+	assert.Nil(t, err)
+	assert.Equal(t, 90*time.Minute, d)
+
+	d, err = mapitf.From(data).Get("timeout").ToDuration()
+	assert.Nil(t, err)
+	assert.Equal(t, 90*time.Second, d)
+
+	tms, err := mapitf.From(data).Get("list").ToListTime()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(tms))
+	assert.True(t, tms[0].Equal(tms[1]))
+
+	_, err = mapitf.From(data).Get("bad").ToTime()
+	assert.NotNil(t, err)
+}
+
+func Test_BigNumber(t *testing.T) {
+	jsonStr := `{"id": 18446744073709551615, "count": "1.5e3", "price": 19.995, "rate": "0.1", "name": "x"}`
+
+	id, err := mapitf.From(jsonStr).Get("id").ToBigInt()
+	assert.Nil(t, err)
+	assert.Equal(t, "18446744073709551615", id.String())
+
+	count, err := mapitf.From(jsonStr).Get("count").ToBigInt()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1500), count.Int64())
+
+	price, err := mapitf.From(jsonStr).Get("price").ToDecimalStr(2)
+	assert.Nil(t, err)
+	assert.Equal(t, "20.00", price)
+
+	rate, err := mapitf.From(jsonStr).Get("rate").ToBigRat()
+	assert.Nil(t, err)
+	assert.Equal(t, "1/10", rate.String())
+
+	f, err := mapitf.From(jsonStr).Get("id").ToBigFloat()
+	assert.Nil(t, err)
+	assert.Equal(t, "18446744073709551615", f.Text('f', 0))
+
+	ids, err := mapitf.GetAs[[]*big.Int](`{"ids": [9223372036854775808, "1e2"]}`, "ids")
+	assert.Nil(t, err)
+	assert.Equal(t, "9223372036854775808", ids[0].String())
+	assert.Equal(t, "100", ids[1].String())
+
+	_, err = mapitf.From(jsonStr).Get("name").ToBigInt()
+	assert.NotNil(t, err)
+	_, err = mapitf.From(jsonStr).Get("price").ToDecimalStr(-1)
+	assert.NotNil(t, err)
+}
+
+func Test_StrictNumberCvt(t *testing.T) {
+	data := map[string]interface{}{
+		"big":   json.Number("3000000000"),
+		"neg":   -1,
+		"frac":  json.Number("1.9"),
+		"sci":   json.Number("1.5e3"),
+		"id":    uint64(18446744073709551615),
+		"ids":   []interface{}{1, json.Number("3000000000")},
+		"score": map[string]interface{}{"1": 1.5},
+	}
+
+	// 默认非严格模式,沿用强转
+	i32, err := mapitf.From(data).Get("big").ToInt32()
+	assert.Nil(t, err)
+	assert.Equal(t, int32(-1294967296), i32)
+	i, err := mapitf.From(data).Get("frac").ToInt()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, i)
+
+	// 单次调用开启严格模式
+	ctx := conf.WithStrictNumberCvt(context.TODO(), true)
+	_, err = mapitf.Fr(ctx, data).Get("big").ToInt32()
+	assert.Equal(t, itferr.NumberOverflow, itferr.GetErrCode(err))
+	_, err = mapitf.Fr(ctx, data).Get("neg").ToUint()
+	assert.Equal(t, itferr.NumberOverflow, itferr.GetErrCode(err))
+	_, err = mapitf.Fr(ctx, data).Get("frac").ToInt64()
+	assert.Equal(t, itferr.NumberPrecisionLoss, itferr.GetErrCode(err))
+	_, err = mapitf.Fr(ctx, data).Get("id").ToInt64()
+	assert.Equal(t, itferr.NumberOverflow, itferr.GetErrCode(err))
+	_, err = mapitf.Fr(ctx, data).Get("id").ToFloat64()
+	assert.Equal(t, itferr.NumberPrecisionLoss, itferr.GetErrCode(err))
+	_, err = mapitf.Fr(ctx, data).Get("ids").ToListInt32()
+	assert.Equal(t, itferr.NumberOverflow, itferr.GetErrCode(err))
+	_, err = mapitf.Fr(ctx, data).Get("score").ToMapIntToInt()
+	assert.Equal(t, itferr.NumberPrecisionLoss, itferr.GetErrCode(err))
+
+	sci, err := mapitf.Fr(ctx, data).Get("sci").ToInt32()
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1500), sci)
+	u64, err := mapitf.Fr(ctx, data).Get("id").ToUint64()
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(18446744073709551615), u64)
+
+	// 全局开启严格模式,同样作用于list及泛型转换
+	mapitf.Config().SetStrictNumberCvt(true)
+	defer mapitf.Config().SetStrictNumberCvt(false)
+	_, err = mapitf.From(data).Get("ids").ToListUint32()
+	assert.Nil(t, err)
+	_, err = mapitf.GetAs[[]int16](data, "ids")
+	assert.Equal(t, itferr.NumberOverflow, itferr.GetErrCode(err))
+	_, err = mapitf.From(data).Get("big").ToInt32()
+	assert.Equal(t, itferr.NumberOverflow, itferr.GetErrCode(err))
+	i32, err = mapitf.Fr(conf.WithStrictNumberCvt(context.TODO(), false), data).Get("big").ToInt32()
+	assert.Nil(t, err)
+	assert.Equal(t, int32(-1294967296), i32)
+}
+
+func Test_ToStructList(t *testing.T) {
+	type Name struct {
+		First string `json:"first"`
+		Last  string `json:"last"`
+	}
+	type User struct {
+		Name Name `json:"name"`
+		Age  int  `json:"age"`
+	}
+
+	jsonStr := `{"users": [{"name": {"first": "Tom", "last": "Anderson"}, "age": 37}, {"name": {"first": "Roger"}, "age": "68"}],
+		"groups": {"1": {"name": {"first": "Jane"}, "age": 47}, "2": "{\"name\": {\"first\": \"Dale\"}, \"age\": 44}"}}`
+
+	var users []User
+	_, err := mapitf.From(jsonStr).Get("users").ToStructList(&users)
+	assert.Nil(t, err)
+	assert.Equal(t, []User{{Name: Name{First: "Tom", Last: "Anderson"}, Age: 37}, {Name: Name{First: "Roger"}, Age: 68}}, users)
+
+	var userPtrs []*User
+	listMap := []map[string]interface{}{{"age": 1}, {"age": json.Number("2")}}
+	_, err = mapitf.From(listMap).ToStructList(&userPtrs)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, userPtrs[1].Age)
+
+	groups := make(map[int]User)
+	_, err = mapitf.From(jsonStr).Get("groups").ToStructMap(&groups)
+	assert.Nil(t, err)
+	assert.Equal(t, "Jane", groups[1].Name.First)
+	assert.Equal(t, 44, groups[2].Age)
+
+	badList := []interface{}{map[string]interface{}{"age": 1}, map[string]interface{}{"name": map[string]interface{}{"first": []int{1}}}}
+	_, err = mapitf.From(badList).ToStructList(&users)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "ToStructList(1)")
+	assert.Contains(t, err.Error(), "Name.First")
+
+	mapitf.Config().SetSkipCvtFailForToArrayType(true)
+	defer mapitf.Config().SetSkipCvtFailForToArrayType(false)
+	_, err = mapitf.From(badList).ToStructList(&users)
+	assert.Nil(t, err)
+	assert.Equal(t, []User{{Age: 1}}, users)
+
+	_, err = mapitf.From(badList).ToStructList(users)
+	assert.NotNil(t, err)
+}
+
+func Test_ToStructOpt(t *testing.T) {
+	type UserDO struct {
+		UserName string
+		Age      int
+		Tags     []string
+	}
+	type UserVO struct {
+		UserName string `json:"user_name" db:"name"`
+		Age      int64  `json:"age" db:"user_age"`
+		Tags     []string
+	}
+
+	// struct转换为其他struct
+	vo := UserVO{}
+	_, err := mapitf.From(UserDO{UserName: "Tom", Age: 18, Tags: []string{"a"}}).ToStruct(&vo)
+	assert.Nil(t, err)
+	assert.Equal(t, UserVO{UserName: "Tom", Age: 18, Tags: []string{"a"}}, vo)
+
+	// 同类型直接赋值
+	do := UserDO{}
+	_, err = mapitf.From(UserDO{UserName: "Jerry"}).ToStruct(&do)
+	assert.Nil(t, err)
+	assert.Equal(t, "Jerry", do.UserName)
+
+	// map按json tag解码
+	vo = UserVO{}
+	_, err = mapitf.From(map[string]interface{}{"user_name": "Tom", "age": "18"}).ToStruct(&vo, api.StructOpt{TagName: "json"})
+	assert.Nil(t, err)
+	assert.Equal(t, UserVO{UserName: "Tom", Age: 18}, vo)
+
+	// 自定义tag,json str同样按该tag解码
+	vo = UserVO{}
+	_, err = mapitf.From(`{"name": "Tom", "user_age": "20"}`).ToStruct(&vo, api.StructOpt{TagName: "db"})
+	assert.Nil(t, err)
+	assert.Equal(t, UserVO{UserName: "Tom", Age: 20}, vo)
+
+	var vos []UserVO
+	_, err = mapitf.From(`[{"user_name": "Tom"}, {"user_name": "Jerry"}]`).ToStructList(&vos, api.StructOpt{TagName: "json"})
+	assert.Nil(t, err)
+	assert.Equal(t, "Jerry", vos[1].UserName)
+
+	// 字段级别的错误
+	_, err = mapitf.From(map[string]interface{}{"user_name": []int{1}, "age": "x", "Tags": "y"}).ToStruct(&vo, api.StructOpt{TagName: "json"})
+	assert.NotNil(t, err)
+	var fieldErr *itferr.StructFieldErr
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, 2, len(fieldErr.Fields))
+	assert.Equal(t, "age", fieldErr.Fields[0].Path)
+	assert.Equal(t, "user_name", fieldErr.Fields[1].Path)
+}
+
+type structBase struct {
+	ID      int64 `json:"id"`
+	Creator string
+}
+
+type StructItem struct {
+	SkuID int64  `json:"sku_id"`
+	Title string `json:"title,omitempty"`
+}
+
+type StructResp struct {
+	structBase
+	Code   int            `json:"code"`
+	Msg    string         `json:"msg,omitempty"`
+	Data   *StructItem    `json:"data"`
+	Items  []StructItem   `json:"items"`
+	Secret string         `json:"-"`
+	Extra  map[string]int `json:"extra,omitempty"`
+	inner  int
+}
+
+func Test_StructNode(t *testing.T) {
+	resp := StructResp{
+		structBase: structBase{ID: 7, Creator: "sys"},
+		Code:       200,
+		Data:       &StructItem{SkuID: 1001, Title: "apple"},
+		Items:      []StructItem{{SkuID: 1}, {SkuID: 2, Title: "pear"}},
+		Secret:     "x",
+		inner:      1,
+	}
+
+	m, err := mapitf.From(resp).ToMap()
+	assert.Nil(t, err)
+	assert.Equal(t, 5, len(m))
+	assert.Equal(t, int64(7), m["id"])
+	assert.Equal(t, "sys", m["Creator"])
+	assert.Equal(t, resp.Data, m["data"])
+
+	deep, err := pkg.StructToMap(&resp, pkg.StructMapOpt{Deep: true})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"sku_id": int64(1001), "title": "apple"}, deep["data"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"sku_id": int64(1)}, map[string]interface{}{"sku_id": int64(2), "title": "pear"}}, deep["items"])
+
+	sku, err := mapitf.From(&resp).Get("data").Get("sku_id").ToInt64()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1001), sku)
+
+	title, err := mapitf.From(&resp).Get("items").Index(1).GetAny("title").ToStr()
+	assert.Nil(t, err)
+	assert.Equal(t, "pear", title)
+
+	keys := make([]string, 0)
+	mapitf.From(resp).ForEach(func(i int, k, v interface{}) (interface{}, interface{}) {
+		keys = append(keys, k.(string))
+		return nil, nil
+	})
+	assert.ElementsMatch(t, []string{"id", "Creator", "code", "data", "items"}, keys)
+
+	_, err = mapitf.From(resp).Get("Secret").ToStr()
+	assert.NotNil(t, err)
+
+	// 结构体的ToStr仍优先使用String方法
+	str, err := mapitf.From(&OjbImpStr{Name: "Jack", Age: 24}).ToStr()
+	assert.Nil(t, err)
+	assert.Equal(t, "Name:Jack,Age:24", str)
+}
+
+type structNode struct {
+	Val  int         `json:"val"`
+	Next *structNode `json:"next"`
+}
+
+type structNameA struct {
+	ID   int64  `json:"id"`
+	Name string `json:"Name"`
+	Memo string
+}
+
+type structNameB struct {
+	ID   int64 `json:"id"`
+	Name string
+	Memo string
+}
+
+type structAmbiguous struct {
+	structNameA
+	*structNameB
+	Code int `json:"code"`
+}
+
+func Test_StructToMapRule(t *testing.T) {
+	v := structAmbiguous{structNameA: structNameA{ID: 1, Name: "a", Memo: "x"}, structNameB: &structNameB{ID: 2, Name: "b", Memo: "y"}, Code: 3}
+	m, err := pkg.StructToMap(v)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"Name": "a", "code": 3}, m)
+
+	jsonBytes, _ := json.Marshal(v)
+	jsonMap := make(map[string]interface{})
+	_ = json.Unmarshal(jsonBytes, &jsonMap)
+	assert.Len(t, jsonMap, len(m))
+
+	// nil的嵌入指针同样参与同名字段的取舍
+	m, err = pkg.StructToMap(structAmbiguous{structNameA: structNameA{ID: 1, Name: "a", Memo: "x"}})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"Name": "a", "code": 0}, m)
+
+	node := &structNode{Val: 1, Next: &structNode{Val: 2}}
+	deep, err := pkg.StructToMap(node, pkg.StructMapOpt{Deep: true})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"val": 2, "next": (*structNode)(nil)}, deep["next"])
+
+	node.Next.Next = node
+	_, err = pkg.StructToMap(node, pkg.StructMapOpt{Deep: true})
+	assert.NotNil(t, err)
+	m, err = pkg.StructToMap(node)
+	assert.Nil(t, err)
+	assert.Equal(t, node.Next, m["next"])
+}
+
+func Test_ToTyped(t *testing.T) {
+	jsonStr := `{"flags":{"a":"true","b":0},"tags":{"x":["1",2]},"ids":{"1001":"tom","1002":7},"grid":[[1,"2"],[3.0]],"scores":{"math":[90,"x"]}}`
+
+	var flags map[string]bool
+	assert.Nil(t, mapitf.From(jsonStr).Get("flags").ToTyped(&flags))
+	assert.Equal(t, map[string]bool{"a": true, "b": false}, flags)
+
+	var tags map[string][]string
+	assert.Nil(t, mapitf.From(jsonStr).Get("tags").ToTyped(&tags))
+	assert.Equal(t, map[string][]string{"x": {"1", "2"}}, tags)
+
+	var ids map[int64]string
+	assert.Nil(t, mapitf.From(jsonStr).Get("ids").ToTyped(&ids))
+	assert.Equal(t, map[int64]string{1001: "tom", 1002: "7"}, ids)
+
+	var grid [][]int
+	assert.Nil(t, mapitf.From(jsonStr).Get("grid").ToTyped(&grid))
+	assert.Equal(t, [][]int{{1, 2}, {3}}, grid)
+
+	var scores map[string][]int
+	err := mapitf.From(jsonStr).Get("scores").ToTyped(&scores)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "[math][1]")
+
+	mapitf.Config().SetSkipCvtFailForToArrayType(true)
+	defer mapitf.Config().SetSkipCvtFailForToArrayType(false)
+	assert.Nil(t, mapitf.From(jsonStr).Get("scores").ToTyped(&scores))
+	assert.Equal(t, map[string][]int{"math": {90}}, scores)
+
+	assert.NotNil(t, mapitf.From(jsonStr).ToTyped(scores))
+}
+
+func Test_BoolCvt(t *testing.T) {
+	m := map[string]interface{}{"flags": []interface{}{"Y", "n", "ON", 0, "1.0", true}, "vip": "yes", "level": 2}
+
+	vip, err := mapitf.From(m).Get("vip").ToBool()
+	assert.Nil(t, err)
+	assert.True(t, vip)
+
+	flags, err := mapitf.From(m).Get("flags").ToListBool()
+	assert.Nil(t, err)
+	assert.Equal(t, []bool{true, false, true, false, true, true}, flags)
+
+	mapitf.Config().SetBoolStrs([]string{"是"}, []string{"否"}).SetStrictBoolCvt(true)
+	defer func() {
+		mapitf.Config().SetBoolStrs([]string{"true", "t", "yes", "y", "on"}, []string{"false", "f", "no", "n", "off"}).SetStrictBoolCvt(false)
+	}()
+
+	_, err = mapitf.From(m).Get("vip").ToBool()
+	assert.NotNil(t, err)
+	_, err = mapitf.From(m).Get("level").ToBool()
+	assert.NotNil(t, err)
+
+	var status map[string]bool
+	assert.Nil(t, mapitf.From(`{"a":"是","b":"否","c":1}`).ToTyped(&status))
+	assert.Equal(t, map[string]bool{"a": true, "b": false, "c": true}, status)
+}
+
+type Money struct {
+	Cent int64
+}
+
+func Test_RegisterConverter(t *testing.T) {
+	mapitf.Config().
+		RegisterConverter(reflect.TypeOf(Money{}), reflect.Int64, func(v interface{}) (interface{}, error) {
+			return v.(Money).Cent, nil
+		}).
+		RegisterConverter(reflect.TypeOf(Money{}), reflect.String, func(v interface{}) (interface{}, error) {
+			return fmt.Sprintf("%.2f", float64(v.(Money).Cent)/100), nil
+		}).
+		RegisterConverter(reflect.TypeOf(sql.NullString{}), reflect.String, func(v interface{}) (interface{}, error) {
+			if ns := v.(sql.NullString); ns.Valid {
+				return ns.String, nil
+			}
+			return "", errors.New("null string")
+		}).
+		RegisterConverter(reflect.TypeOf(Money{}), reflect.Struct, func(v interface{}) (interface{}, error) {
+			return time.Unix(v.(Money).Cent, 0), nil
+		})
+	defer func() {
+		mapitf.Config().
+			RegisterConverter(reflect.TypeOf(Money{}), reflect.Int64, nil).
+			RegisterConverter(reflect.TypeOf(Money{}), reflect.String, nil).
+			RegisterConverter(reflect.TypeOf(sql.NullString{}), reflect.String, nil).
+			RegisterConverter(reflect.TypeOf(Money{}), reflect.Struct, nil)
+	}()
+
+	m := map[string]interface{}{
+		"price": Money{Cent: 1250},
+		"list":  []interface{}{Money{Cent: 1}, &Money{Cent: 2}},
+		"name":  sql.NullString{String: "tom", Valid: true},
+		"null":  sql.NullString{},
+	}
+
+	// 转换函数返回的错误
+	_, err := mapitf.From(m).Get("null").ToStr()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "null string")
+
+	// Float64,Duration及数值类转换回退到注册的Int64
+	f64, err := mapitf.From(m).Get("price").ToFloat64()
+	assert.Nil(t, err)
+	assert.Equal(t, 1250.0, f64)
+	f32, err := mapitf.From(m).Get("price").ToFloat32()
+	assert.Nil(t, err)
+	assert.Equal(t, float32(1250), f32)
+	d, err := mapitf.From(m).Get("price").ToDuration()
+	assert.Nil(t, err)
+	assert.Equal(t, 1250*time.Second, d)
+	bi, err := mapitf.From(m).Get("price").ToBigInt()
+	assert.Nil(t, err)
+	assert.Equal(t, "1250", bi.String())
+	ds, err := mapitf.From(m).Get("price").ToDecimalStr(2)
+	assert.Nil(t, err)
+	assert.Equal(t, "1250.00", ds)
+	size, err := mapitf.From(m).Get("price").ToBytesSize()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1250), size)
+	tm, err := mapitf.From(m).Get("price").ToTime()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1250), tm.Unix())
+
+	cent, err := mapitf.From(m).Get("price").ToInt64()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1250), cent)
+
+	cent32, err := mapitf.From(m).Get("price").ToInt32()
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1250), cent32)
+
+	price, err := mapitf.From(m).Get("price").ToStr()
+	assert.Nil(t, err)
+	assert.Equal(t, "12.50", price)
+
+	cents, err := mapitf.From(m).Get("list").ToListInt64()
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{1, 2}, cents)
+
+	name, err := mapitf.From(m).Get("name").ToStr()
+	assert.Nil(t, err)
+	assert.Equal(t, "tom", name)
+
+	var typed map[string]string
+	assert.Nil(t, mapitf.From(map[string]interface{}{"price": m["price"], "name": m["name"]}).ToTyped(&typed))
+	assert.Equal(t, map[string]string{"price": "12.50", "name": "tom"}, typed)
+
+	// 未注册Bool时按ToInt64的结果(1250)判断
+	bl, err := mapitf.From(m).Get("price").ToBool()
+	assert.Nil(t, err)
+	assert.False(t, bl)
+}
+
+func Test_NumberFmt(t *testing.T) {
+	m := map[string]interface{}{"a": 1234567.0, "b": 1e21, "c": json.Number("1.50"), "d": 0.25}
+
+	mapitf.Config().SetNumberFmt(conf.NumberFmt{FloatFmt: 'f'})
+	defer mapitf.Config().SetNumberFmt(conf.NumberFmt{})
+
+	strMap, err := mapitf.From(m).ToMapStrToStr()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"a": "1234567", "b": "1000000000000000000000", "c": "1.50", "d": "0.25"}, strMap)
+
+	// 未指定的字段保持默认: 最少位数,json.Number原样输出
+	s, err := mapitf.From([]interface{}{1.5, json.Number("6911300862917002766")}).ToListStrF()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1.5", "6911300862917002766"}, s)
+
+	mapitf.Config().SetNumberFmt(conf.NumberFmt{FloatFmt: 'f', Prec: conf.Prec(2), TrimZero: true, JsonNumberAsFloat: true})
+	list, err := mapitf.From([]interface{}{1234567.0, json.Number("1.50"), 0.125, float32(3)}).ToListStrF()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1234567", "1.5", "0.12", "3"}, list)
+}
+
+func Test_ParseNumLiteral(t *testing.T) {
+	m := map[string]interface{}{"reg": "0x1F", "mode": "0o17", "mask": "0b1010", "count": "1_000_000", "max": "0xFFFFFFFFFFFFFFFF", "price": "1,234.50"}
+
+	_, err := mapitf.From(m).Get("reg").ToInt64()
+	assert.NotNil(t, err)
+
+	mapitf.Config().SetParseNumLiteral(true)
+	defer mapitf.Config().SetParseNumLiteral(false)
+
+	reg, err := mapitf.From(m).Get("reg").ToInt64()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(31), reg)
+
+	maxV, err := mapitf.From(m).Get("max").ToUint64()
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(18446744073709551615), maxV)
+	maxV, err = mapitf.Fr(conf.WithStrictNumberCvt(context.TODO(), true), m).Get("max").ToUint64()
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(18446744073709551615), maxV)
+
+	list, err := mapitf.From([]interface{}{m["mode"], m["mask"], m["count"]}).ToListInt()
+	assert.Nil(t, err)
+	assert.Equal(t, []int{15, 10, 1000000}, list)
+
+	_, err = mapitf.From(m).Get("price").ToFloat64()
+	assert.NotNil(t, err)
+
+	mapitf.Config().SetThousandsSep(",")
+	defer mapitf.Config().SetThousandsSep("")
+
+	price, err := mapitf.From(m).Get("price").ToFloat64()
+	assert.Nil(t, err)
+	assert.Equal(t, 1234.5, price)
+
+	var typed map[string]int64
+	assert.Nil(t, mapitf.From(`{"a":"0x10","b":"12,000"}`).ToTyped(&typed))
+	assert.Equal(t, map[string]int64{"a": 16, "b": 12000}, typed)
+}
+
+func Test_UnitCvt(t *testing.T) {
+	cfg := `{"cache":{"size":"512MiB","disk":"10GB","ratio":"75%","ttl":"1d12h","bad":"10XB"}}`
+
+	size, err := mapitf.From(cfg).Get("cache").Get("size").ToBytesSize()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(512<<20), size)
+
+	disk, err := mapitf.From(cfg).GetAny("cache", "disk").ToBytesSize()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10_000_000_000), disk)
+
+	ratio, err := mapitf.From(cfg).GetAny("cache", "ratio").ToPercent()
+	assert.Nil(t, err)
+	assert.Equal(t, 0.75, ratio)
+
+	ttl, err := mapitf.From(cfg).GetAny("cache", "ttl").ToDuration()
+	assert.Nil(t, err)
+	assert.Equal(t, 36*time.Hour, ttl)
+
+	_, err = mapitf.From(cfg).GetAny("cache", "bad").ToBytesSize()
+	assert.Equal(t, itferr.UnitParseFailed, itferr.GetErrCode(err))
+	assert.Contains(t, err.Error(), "XB")
+
+	_, err = mapitf.From(cfg).GetAny("cache", "ttl").ToPercent()
+	assert.Equal(t, itferr.UnitParseFailed, itferr.GetErrCode(err))
+}
+
+func Test_ForeachStrictNumberCvt(t *testing.T) {
+	data := map[string]interface{}{"a": json.Number("3000000000"), "b": 1.9}
+	swap := func(i int, k, v interface{}) (interface{}, interface{}) { return v, k }
+
+	// 非严格模式沿用强转
+	result, err := mapitf.From(data).ForEach(swap).ToMapInt32()
+	assert.Nil(t, err)
+	assert.Equal(t, "b", result[1])
+
+	ctx := conf.WithStrictNumberCvt(context.TODO(), true)
+	_, err = mapitf.Fr(ctx, data).ForEach(swap).ToMapInt32()
+	assert.NotNil(t, err)
+	_, err = mapitf.Fr(ctx, map[string]interface{}{"a": 1.5}).ForEach(swap).ToMapInt64()
+	assert.Equal(t, itferr.NumberPrecisionLoss, itferr.GetErrCode(err))
+	_, err = mapitf.Fr(ctx, map[string]interface{}{"a": -1}).ForEach(swap).ToMapUint()
+	assert.Equal(t, itferr.NumberOverflow, itferr.GetErrCode(err))
+
+	mapitf.Config().SetSkipCvtFailForToMapType(true)
+	defer mapitf.Config().SetSkipCvtFailForToMapType(false)
+	skipped, err := mapitf.Fr(ctx, data).ForEach(swap).ToMapInt32()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(skipped))
+	ints, err := mapitf.From(map[string]interface{}{"x": 1, "y": 2}).ForEach(swap).ToMapIntToInt()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(ints))
+}
+
+func Test_StrictNumberCvtCtx(t *testing.T) {
+	data := map[string]interface{}{"ids": []interface{}{1, json.Number("3000000000")}, "rate": 1.5}
+	ctx := conf.WithStrictNumberCvt(context.TODO(), true)
+
+	_, err := mapitf.As[[]int32](mapitf.Fr(ctx, data).Get("ids"))
+	assert.Equal(t, itferr.NumberOverflow, itferr.GetErrCode(err))
+	_, err = mapitf.Collect[int32](mapitf.Fr(ctx, data).Get("ids"))
+	assert.Equal(t, itferr.NumberOverflow, itferr.GetErrCode(err))
+	var rate int
+	err = mapitf.Fr(ctx, data).Get("rate").ToTyped(&rate)
+	assert.Equal(t, itferr.NumberPrecisionLoss, itferr.GetErrCode(err))
+
+	// 未开启时沿用强转
+	ids, err := mapitf.As[[]int32](mapitf.From(data).Get("ids"))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(ids))
+	assert.Nil(t, mapitf.From(data).Get("rate").ToTyped(&rate))
+	assert.Equal(t, 1, rate)
+}
+
+func Test_BoolCvtNumber(t *testing.T) {
+	// 非严格模式沿用原有规则,仅取整后为1时为true
+	for v, want := range map[interface{}]bool{1: true, 2: false, -1: false, "1.0": true, 0: false} {
+		got, err := mapitf.From(v).ToBool()
+		assert.Nil(t, err)
+		assert.Equal(t, want, got, "%v", v)
+	}
+	list, err := mapitf.From([]interface{}{2, 1, 0}).ToListBool()
+	assert.Nil(t, err)
+	assert.Equal(t, []bool{false, true, false}, list)
+}
@@ -1,6 +1,6 @@
 module github.com/runingriver/mapinterface
 
-go 1.17
+go 1.18
 
 require (
 	github.com/bytedance/sonic v1.12.2
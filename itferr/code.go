@@ -17,14 +17,20 @@ const (
 	GetFuncTypeInconsistent MapItfErrorCode = 3006
 	IllegalMapObject        MapItfErrorCode = 3007
 	EmptyMapObject          MapItfErrorCode = 3008
+	NumberOverflow          MapItfErrorCode = 3009
+	NumberPrecisionLoss     MapItfErrorCode = 3010
+	UnitParseFailed         MapItfErrorCode = 3011
 
 	ListIndexIllegal MapItfErrorCode = 4001
+	EmptyListObject  MapItfErrorCode = 4002
 
 	UnSupportInterfaceFunc MapItfErrorCode = 5001
 	CurrentCannotUseIndex  MapItfErrorCode = 5002
 	TypeMismatchErr        MapItfErrorCode = 5003
 	FuncUsedErr            MapItfErrorCode = 5004
 	UnrecoverablePanicErr  MapItfErrorCode = 5005
+	IterCanceled           MapItfErrorCode = 5006
+	IterCallbackErr        MapItfErrorCode = 5007
 
 	SetValueErr              MapItfErrorCode = 6001
 	UnSupportSetValTypeErr   MapItfErrorCode = 6002
@@ -3,6 +3,7 @@ package itferr
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 type MapItfErr interface {
@@ -47,6 +48,10 @@ func NewListIndexIllegal(locate string) *MapItfError {
 	return NewMapItfErr(locate, ListIndexIllegal, "", nil)
 }
 
+func NewEmptyListObject(locate string) *MapItfError {
+	return NewMapItfErr(locate, EmptyListObject, "", nil)
+}
+
 func NewKeyTypeErr(locate string) *MapItfError {
 	return NewMapItfErr(locate, KeyTypeErr, "", nil)
 }
@@ -63,10 +68,27 @@ func NewConvFailed(locate string) *MapItfError {
 	return NewMapItfErr(locate, ValueConvertFailed, "", nil)
 }
 
+// NewConvFailedX err为数值溢出,精度丢失或单位解析错误时,沿用其错误码,便于调用方通过Code()区分
 func NewConvFailedX(locate string, msg string, err error) *MapItfError {
+	var numErr *MapItfError
+	if errors.As(err, &numErr) && (numErr.ErrCode == NumberOverflow || numErr.ErrCode == NumberPrecisionLoss || numErr.ErrCode == UnitParseFailed) {
+		return NewMapItfErr(locate, numErr.ErrCode, msg, err)
+	}
 	return NewMapItfErr(locate, ValueConvertFailed, msg, err)
 }
 
+func NewNumberOverflowErr(locate string, msg string) *MapItfError {
+	return NewMapItfErr(locate, NumberOverflow, msg, nil)
+}
+
+func NewNumberPrecisionLossErr(locate string, msg string) *MapItfError {
+	return NewMapItfErr(locate, NumberPrecisionLoss, msg, nil)
+}
+
+func NewUnitParseErr(locate string, msg string) *MapItfError {
+	return NewMapItfErr(locate, UnitParseFailed, msg, nil)
+}
+
 func NewUnSupportInterfaceFunc(locate string) *MapItfError {
 	return NewMapItfErr(locate, UnSupportInterfaceFunc, "", nil)
 }
@@ -103,11 +125,34 @@ func NewSetValueErr(locate string, msg string, err error) *MapItfError {
 	return NewMapItfErr(locate, SetValueErr, msg, err)
 }
 
+func NewIterCallbackErr(locate string, err error) *MapItfError {
+	return NewMapItfErr(locate, IterCallbackErr, "", err)
+}
+
+// FieldErr 单个字段的转换错误,Path为字段路径,如:name.first
+type FieldErr struct {
+	Path   string
+	Reason string
+}
+
+// StructFieldErr 转换struct时所有失败的字段
+type StructFieldErr struct {
+	Fields []FieldErr
+}
+
+func (sfe *StructFieldErr) Error() string {
+	reasons := make([]string, 0, len(sfe.Fields))
+	for _, f := range sfe.Fields {
+		reasons = append(reasons, f.Reason)
+	}
+	return fmt.Sprintf("%d field(s) convert failed: %s", len(sfe.Fields), strings.Join(reasons, "; "))
+}
+
 func (mie *MapItfError) String() string {
 	if mie.Err == nil && mie.ErrMsg == "" {
 		return fmt.Sprintf("MapItfError{Location:%s,ErrCode:%d(%s)}", mie.Location, mie.ErrCode, mie.ErrCode.String())
 	}
-	if mie.ErrMsg != "" {
+	if mie.Err == nil {
 		return fmt.Sprintf("MapItfError{Location:%s,ErrCode:%d(%s),ErrMsg:%s}", mie.Location, mie.ErrCode, mie.ErrCode.String(), mie.ErrMsg)
 	}
 	return fmt.Sprintf("MapItfError{Location:%s,ErrCode:%d(%s),Err:%s,ErrMsg:%s}", mie.Location, mie.ErrCode, mie.ErrCode.String(), mie.Err.Error(), mie.ErrMsg)
@@ -122,6 +167,11 @@ func (mie *MapItfError) Wrap(err error) MapItfErr {
 	return mie
 }
 
+// Unwrap 便于通过errors.Is/errors.As判断被包装的原始错误
+func (mie *MapItfError) Unwrap() error {
+	return mie.Err
+}
+
 func (mie *MapItfError) Is(err error) bool {
 	return IsItfErr(err)
 }
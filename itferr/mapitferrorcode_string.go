@@ -20,12 +20,18 @@ func _() {
 	_ = x[GetFuncTypeInconsistent-3006]
 	_ = x[IllegalMapObject-3007]
 	_ = x[EmptyMapObject-3008]
+	_ = x[NumberOverflow-3009]
+	_ = x[NumberPrecisionLoss-3010]
+	_ = x[UnitParseFailed-3011]
 	_ = x[ListIndexIllegal-4001]
+	_ = x[EmptyListObject-4002]
 	_ = x[UnSupportInterfaceFunc-5001]
 	_ = x[CurrentCannotUseIndex-5002]
 	_ = x[TypeMismatchErr-5003]
 	_ = x[FuncUsedErr-5004]
 	_ = x[UnrecoverablePanicErr-5005]
+	_ = x[IterCanceled-5006]
+	_ = x[IterCallbackErr-5007]
 	_ = x[SetValueErr-6001]
 	_ = x[UnSupportSetValTypeErr-6002]
 	_ = x[IterChainIsEmpty-6003]
@@ -36,16 +42,17 @@ const (
 	_MapItfErrorCode_name_0 = "UnknownErr"
 	_MapItfErrorCode_name_1 = "InitParseFailed"
 	_MapItfErrorCode_name_2 = "InitParamTypeErrExceptObject"
-	_MapItfErrorCode_name_3 = "KeyTypeErrValueTypeErrValueConvertFailedBaseTypeConvertFailedKeyNotFoundGetFuncTypeInconsistentIllegalMapObjectEmptyMapObject"
-	_MapItfErrorCode_name_4 = "ListIndexIllegal"
-	_MapItfErrorCode_name_5 = "UnSupportInterfaceFuncCurrentCannotUseIndexTypeMismatchErrFuncUsedErrUnrecoverablePanicErr"
+	_MapItfErrorCode_name_3 = "KeyTypeErrValueTypeErrValueConvertFailedBaseTypeConvertFailedKeyNotFoundGetFuncTypeInconsistentIllegalMapObjectEmptyMapObjectNumberOverflowNumberPrecisionLossUnitParseFailed"
+	_MapItfErrorCode_name_4 = "ListIndexIllegalEmptyListObject"
+	_MapItfErrorCode_name_5 = "UnSupportInterfaceFuncCurrentCannotUseIndexTypeMismatchErrFuncUsedErrUnrecoverablePanicErrIterCanceledIterCallbackErr"
 	_MapItfErrorCode_name_6 = "SetValueErrUnSupportSetValTypeErrIterChainIsEmptyIterChainPreElementIsNil"
 )
 
 var (
 	_MapItfErrorCode_index_2 = [...]uint8{0, 16, 28}
-	_MapItfErrorCode_index_3 = [...]uint8{0, 10, 22, 40, 61, 72, 95, 111, 125}
-	_MapItfErrorCode_index_5 = [...]uint8{0, 22, 43, 58, 69, 90}
+	_MapItfErrorCode_index_3 = [...]uint8{0, 10, 22, 40, 61, 72, 95, 111, 125, 139, 158, 173}
+	_MapItfErrorCode_index_4 = [...]uint8{0, 16, 31}
+	_MapItfErrorCode_index_5 = [...]uint8{0, 22, 43, 58, 69, 90, 102, 117}
 	_MapItfErrorCode_index_6 = [...]uint8{0, 11, 33, 49, 73}
 )
 
@@ -58,12 +65,13 @@ func (i MapItfErrorCode) String() string {
 	case 2001 <= i && i <= 2002:
 		i -= 2001
 		return _MapItfErrorCode_name_2[_MapItfErrorCode_index_2[i]:_MapItfErrorCode_index_2[i+1]]
-	case 3001 <= i && i <= 3008:
+	case 3001 <= i && i <= 3011:
 		i -= 3001
 		return _MapItfErrorCode_name_3[_MapItfErrorCode_index_3[i]:_MapItfErrorCode_index_3[i+1]]
-	case i == 4001:
-		return _MapItfErrorCode_name_4
-	case 5001 <= i && i <= 5005:
+	case 4001 <= i && i <= 4002:
+		i -= 4001
+		return _MapItfErrorCode_name_4[_MapItfErrorCode_index_4[i]:_MapItfErrorCode_index_4[i+1]]
+	case 5001 <= i && i <= 5007:
 		i -= 5001
 		return _MapItfErrorCode_name_5[_MapItfErrorCode_index_5[i]:_MapItfErrorCode_index_5[i+1]]
 	case 6001 <= i && i <= 6004:
@@ -0,0 +1,157 @@
+package mapitf
+
+import (
+	"fmt"
+	"github.com/runingriver/mapinterface/conf"
+	"github.com/runingriver/mapinterface/itferr"
+	"github.com/runingriver/mapinterface/logx"
+	"github.com/runingriver/mapinterface/pkg"
+	"math"
+	"sort"
+)
+
+// AggregateType ----------------------------------------------------------------------------------------
+func (b *BaseItfImpl) Sum(path ...interface{}) (float64, error) {
+	nums, err := b.collectFloat64("Sum", path...)
+	if err != nil {
+		return 0, err
+	}
+
+	sum := 0.0
+	for _, n := range nums {
+		sum += n
+	}
+	return sum, nil
+}
+
+func (b *BaseItfImpl) Avg(path ...interface{}) (float64, error) {
+	nums, err := b.collectFloat64("Avg", path...)
+	if err != nil {
+		return 0, err
+	}
+	if len(nums) == 0 {
+		return 0, itferr.NewEmptyListObject(fmt.Sprintf("%s#Avg", b.Class))
+	}
+
+	sum := 0.0
+	for _, n := range nums {
+		sum += n
+	}
+	return sum / float64(len(nums)), nil
+}
+
+func (b *BaseItfImpl) Min(path ...interface{}) (float64, error) {
+	nums, err := b.collectFloat64("Min", path...)
+	if err != nil {
+		return 0, err
+	}
+	if len(nums) == 0 {
+		return 0, itferr.NewEmptyListObject(fmt.Sprintf("%s#Min", b.Class))
+	}
+
+	result := nums[0]
+	for _, n := range nums[1:] {
+		result = math.Min(result, n)
+	}
+	return result, nil
+}
+
+func (b *BaseItfImpl) Max(path ...interface{}) (float64, error) {
+	nums, err := b.collectFloat64("Max", path...)
+	if err != nil {
+		return 0, err
+	}
+	if len(nums) == 0 {
+		return 0, itferr.NewEmptyListObject(fmt.Sprintf("%s#Max", b.Class))
+	}
+
+	result := nums[0]
+	for _, n := range nums[1:] {
+		result = math.Max(result, n)
+	}
+	return result, nil
+}
+
+func (b *BaseItfImpl) Count(path ...interface{}) (int, error) {
+	nums, err := b.collectFloat64("Count", path...)
+	if err != nil {
+		return 0, err
+	}
+	return len(nums), nil
+}
+
+func (b *BaseItfImpl) Percentile(p float64, path ...interface{}) (float64, error) {
+	if p < 0 || p > 100 || math.IsNaN(p) {
+		return 0, itferr.NewFuncUsedErr(fmt.Sprintf("%s#Percentile(%v)", b.Class, p), "p must be in [0,100]")
+	}
+	nums, err := b.collectFloat64("Percentile", path...)
+	if err != nil {
+		return 0, err
+	}
+	if len(nums) == 0 {
+		return 0, itferr.NewEmptyListObject(fmt.Sprintf("%s#Percentile", b.Class))
+	}
+
+	sort.Float64s(nums)
+	// 线性插值: 位置rank落在[lower,upper]之间时按比例取值
+	rank := p / 100 * float64(len(nums)-1)
+	lower, upper := int(math.Floor(rank)), int(math.Ceil(rank))
+	return nums[lower] + (nums[upper]-nums[lower])*(rank-float64(lower)), nil
+}
+
+func (b *BaseItfImpl) StdDev(path ...interface{}) (float64, error) {
+	nums, err := b.collectFloat64("StdDev", path...)
+	if err != nil {
+		return 0, err
+	}
+	if len(nums) == 0 {
+		return 0, itferr.NewEmptyListObject(fmt.Sprintf("%s#StdDev", b.Class))
+	}
+
+	sum := 0.0
+	for _, n := range nums {
+		sum += n
+	}
+	mean, variance := sum/float64(len(nums)), 0.0
+	for _, n := range nums {
+		variance += (n - mean) * (n - mean)
+	}
+	return math.Sqrt(variance / float64(len(nums))), nil
+}
+
+// collectFloat64 将当前list的元素(或元素按path取到的值)转换为float64,供聚合函数使用
+func (b *BaseItfImpl) collectFloat64(funcName string, path ...interface{}) ([]float64, error) {
+	if b.ItfErr != nil {
+		return nil, b.ItfErr
+	}
+
+	listItf, err := b.ToList()
+	if err != nil {
+		return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#%s", b.Class, funcName), "val is not list", err)
+	}
+
+	result := make([]float64, 0, len(listItf))
+	for i, item := range listItf {
+		if len(path) > 0 {
+			if item, err = Fr(b.Ctx, item).GetAny(path...).Val(); err != nil {
+				if conf.CONF.SkipCvtFailForToArrayType {
+					continue
+				}
+				return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#%s(%d)", b.Class, funcName, i), "get val by path err", err)
+			}
+		}
+
+		f, cvtErr := pkg.ToFloat64(item)
+		if cvtErr != nil {
+			if conf.CONF.SkipCvtFailForToArrayType {
+				continue
+			}
+			return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#%s(%d)", b.Class, funcName, i), "list val not float64 type", cvtErr)
+		}
+		result = append(result, f)
+	}
+	if len(result) != len(listItf) {
+		logx.CtxWarn(b.Ctx, "%s %d list val convert failed", funcName, len(listItf)-len(result))
+	}
+	return result, nil
+}
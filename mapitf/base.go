@@ -10,8 +10,10 @@ import (
 	"github.com/runingriver/mapinterface/itferr"
 	"github.com/runingriver/mapinterface/logx"
 	"github.com/runingriver/mapinterface/pkg"
+	"math/big"
 	"reflect"
 	"strings"
+	"time"
 )
 
 type BaseItfImpl struct {
@@ -78,6 +80,16 @@ func (b *BaseItfImpl) GetByInterface(key interface{}) (srcVal, itfVal interface{
 		}
 	}
 
+	if pkg.IsStruct(b.IterVal) {
+		if structMap, err := pkg.StructToMap(b.IterVal); err == nil {
+			val, ok := structMap[pkg.ToStr(key)]
+			if !ok {
+				b.ItfErr = itferr.NewKeyNotFoundFailed(fmt.Sprintf("GetByInterface#Get(%s)", key))
+			}
+			return structMap, val, b.ItfErr
+		}
+	}
+
 	v := pkg.ReflectToVal(b.IterVal)
 	if v.Kind() != reflect.Map {
 		b.ItfErr = itferr.NewValueTypeErr(fmt.Sprintf("%s#GetByInterface(%+v)", b.Class, key))
@@ -118,6 +130,39 @@ func (b *BaseItfImpl) toInterface(dstVal reflect.Value) (interface{}, itferr.Map
 	return dstVal.Interface(), nil
 }
 
+// context 当前节点的ctx,供As,Collect等非方法的转换读取单次调用级别的配置
+func (b *BaseItfImpl) context() context.Context {
+	return b.Ctx
+}
+
+// toIntN,toUintN,toFloatN,toFloat32 数值转换,是否使用严格模式见conf.IsStrictNumberCvt
+func (b *BaseItfImpl) toIntN(v interface{}, bitSize int) (int64, error) {
+	return pkg.ToIntN(v, bitSize, conf.IsStrictNumberCvt(b.Ctx))
+}
+
+func (b *BaseItfImpl) toUintN(v interface{}, bitSize int) (uint64, error) {
+	return pkg.ToUintN(v, bitSize, conf.IsStrictNumberCvt(b.Ctx))
+}
+
+func (b *BaseItfImpl) toFloatN(v interface{}, bitSize int) (float64, error) {
+	return pkg.ToFloatN(v, bitSize, conf.IsStrictNumberCvt(b.Ctx))
+}
+
+func (b *BaseItfImpl) toFloat32(v interface{}) (float32, error) {
+	f, err := b.toFloatN(v, 32)
+	return float32(f), err
+}
+
+// firstErr 返回第一个不为nil的error
+func firstErr(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (b *BaseItfImpl) Val() (interface{}, error) {
 	if b.ItfErr != nil {
 		return "", b.ItfErr
@@ -129,7 +174,11 @@ func (b *BaseItfImpl) ToStr() (string, error) {
 	if b.ItfErr != nil {
 		return "", b.ItfErr
 	}
-	return pkg.ToStr(b.IterVal), nil
+	s, err := pkg.ToStrE(b.IterVal)
+	if err != nil {
+		return "", itferr.NewConvFailedX(fmt.Sprintf("%s#ToStr", b.Class), "", err)
+	}
+	return s, nil
 }
 
 func (b *BaseItfImpl) ToByte() ([]byte, error) {
@@ -152,11 +201,11 @@ func (b *BaseItfImpl) ToInt() (int, error) {
 		return v, nil
 	}
 
-	if v, ok := pkg.ToInt64(b.IterVal); ok == nil {
-		return int(v), nil
+	v, err := b.toIntN(b.IterVal, 0)
+	if err != nil {
+		return 0, itferr.NewConvFailedX(fmt.Sprintf("%s#ToInt", b.Class), "", err)
 	}
-
-	return 0, itferr.NewConvFailed(fmt.Sprintf("%s#ToInt", b.Class))
+	return int(v), nil
 }
 
 func (b *BaseItfImpl) ToInt64() (int64, error) {
@@ -168,11 +217,11 @@ func (b *BaseItfImpl) ToInt64() (int64, error) {
 		return v, nil
 	}
 
-	if v, ok := pkg.ToInt64(b.IterVal); ok == nil {
-		return v, nil
+	v, err := b.toIntN(b.IterVal, 64)
+	if err != nil {
+		return 0, itferr.NewConvFailedX(fmt.Sprintf("%s#ToInt64", b.Class), "", err)
 	}
-
-	return 0, itferr.NewConvFailed(fmt.Sprintf("%s#ToInt64", b.Class))
+	return v, nil
 }
 
 func (b *BaseItfImpl) ToInt32() (int32, error) {
@@ -184,11 +233,11 @@ func (b *BaseItfImpl) ToInt32() (int32, error) {
 		return v, nil
 	}
 
-	if v, ok := pkg.ToInt64(b.IterVal); ok == nil {
-		return int32(v), nil
+	v, err := b.toIntN(b.IterVal, 32)
+	if err != nil {
+		return 0, itferr.NewConvFailedX(fmt.Sprintf("%s#ToInt32", b.Class), "", err)
 	}
-
-	return 0, itferr.NewConvFailed(fmt.Sprintf("%s#ToInt32", b.Class))
+	return int32(v), nil
 }
 
 func (b *BaseItfImpl) ToRune() (rune, error) {
@@ -200,11 +249,11 @@ func (b *BaseItfImpl) ToRune() (rune, error) {
 		return v, nil
 	}
 
-	if v, ok := pkg.ToInt64(b.IterVal); ok == nil {
-		return rune(v), nil
+	v, err := b.toIntN(b.IterVal, 32)
+	if err != nil {
+		return 0, itferr.NewConvFailedX(fmt.Sprintf("%s#ToRune", b.Class), "", err)
 	}
-
-	return 0, itferr.NewConvFailed(fmt.Sprintf("%s#ToRune", b.Class))
+	return rune(v), nil
 }
 
 func (b *BaseItfImpl) ToUint() (uint, error) {
@@ -216,11 +265,11 @@ func (b *BaseItfImpl) ToUint() (uint, error) {
 		return v, nil
 	}
 
-	if v, ok := pkg.ToInt64(b.IterVal); ok == nil {
-		return uint(v), nil
+	v, err := b.toUintN(b.IterVal, 0)
+	if err != nil {
+		return 0, itferr.NewConvFailedX(fmt.Sprintf("%s#ToUint", b.Class), "", err)
 	}
-
-	return 0, itferr.NewConvFailed(fmt.Sprintf("%s#ToUint", b.Class))
+	return uint(v), nil
 }
 
 func (b *BaseItfImpl) ToUint64() (uint64, error) {
@@ -232,11 +281,11 @@ func (b *BaseItfImpl) ToUint64() (uint64, error) {
 		return v, nil
 	}
 
-	if v, ok := pkg.ToInt64(b.IterVal); ok == nil {
-		return uint64(v), nil
+	v, err := b.toUintN(b.IterVal, 64)
+	if err != nil {
+		return 0, itferr.NewConvFailedX(fmt.Sprintf("%s#ToUint64", b.Class), "", err)
 	}
-
-	return 0, itferr.NewConvFailed(fmt.Sprintf("%s#ToUint64", b.Class))
+	return v, nil
 }
 
 func (b *BaseItfImpl) ToUint32() (uint32, error) {
@@ -248,11 +297,11 @@ func (b *BaseItfImpl) ToUint32() (uint32, error) {
 		return v, nil
 	}
 
-	if v, ok := pkg.ToInt64(b.IterVal); ok == nil {
-		return uint32(v), nil
+	v, err := b.toUintN(b.IterVal, 32)
+	if err != nil {
+		return 0, itferr.NewConvFailedX(fmt.Sprintf("%s#ToUint32", b.Class), "", err)
 	}
-
-	return 0, itferr.NewConvFailed(fmt.Sprintf("%s#ToUint32", b.Class))
+	return uint32(v), nil
 }
 
 func (b *BaseItfImpl) ToFloat32() (float32, error) {
@@ -264,11 +313,11 @@ func (b *BaseItfImpl) ToFloat32() (float32, error) {
 		return v, nil
 	}
 
-	if v, ok := pkg.ToFloat64(b.IterVal); ok == nil {
-		return float32(v), nil
+	v, err := b.toFloatN(b.IterVal, 32)
+	if err != nil {
+		return 0, itferr.NewConvFailedX(fmt.Sprintf("%s#ToFloat", b.Class), "", err)
 	}
-
-	return 0, itferr.NewConvFailed(fmt.Sprintf("%s#ToFloat", b.Class))
+	return float32(v), nil
 }
 
 func (b *BaseItfImpl) ToFloat64() (float64, error) {
@@ -280,11 +329,11 @@ func (b *BaseItfImpl) ToFloat64() (float64, error) {
 		return v, nil
 	}
 
-	if v, ok := pkg.ToFloat64(b.IterVal); ok == nil {
-		return v, nil
+	v, err := b.toFloatN(b.IterVal, 64)
+	if err != nil {
+		return 0, itferr.NewConvFailedX(fmt.Sprintf("%s#ToFloat64", b.Class), "", err)
 	}
-
-	return 0, itferr.NewConvFailed(fmt.Sprintf("%s#ToFloat64", b.Class))
+	return v, nil
 }
 
 func (b *BaseItfImpl) ToBool() (bool, error) {
@@ -292,23 +341,111 @@ func (b *BaseItfImpl) ToBool() (bool, error) {
 		return false, b.ItfErr
 	}
 
-	if v, ok := b.IterVal.(bool); ok {
-		return v, nil
+	v, err := pkg.ToBool(b.IterVal)
+	if err != nil {
+		return false, itferr.NewConvFailedX(fmt.Sprintf("%s#ToBool", b.Class), "", err)
 	}
+	return v, nil
+}
+
+func (b *BaseItfImpl) ToTime(layouts ...string) (time.Time, error) {
+	return b.ToTimeIn(time.Local, layouts...)
+}
 
-	if v, ok := pkg.ToInt64(b.IterVal); ok == nil {
-		return v == 1, nil
+func (b *BaseItfImpl) ToTimeIn(loc *time.Location, layouts ...string) (time.Time, error) {
+	if b.ItfErr != nil {
+		return time.Time{}, b.ItfErr
 	}
 
-	v := strings.TrimSpace(pkg.ToStr(b.IterVal))
-	if v == "true" {
-		return true, nil
+	t, err := pkg.ToTime(b.IterVal, loc, layouts...)
+	if err != nil {
+		return time.Time{}, itferr.NewConvFailedX(fmt.Sprintf("%s#ToTime", b.Class), "", err)
 	}
-	if v == "false" {
-		return false, nil
+	return t, nil
+}
+
+func (b *BaseItfImpl) ToDuration() (time.Duration, error) {
+	if b.ItfErr != nil {
+		return 0, b.ItfErr
 	}
 
-	return false, itferr.NewConvFailed(fmt.Sprintf("%s#ToBool", b.Class))
+	d, err := pkg.ToDuration(b.IterVal)
+	if err != nil {
+		return 0, itferr.NewConvFailedX(fmt.Sprintf("%s#ToDuration", b.Class), "", err)
+	}
+	return d, nil
+}
+
+func (b *BaseItfImpl) ToBytesSize() (int64, error) {
+	if b.ItfErr != nil {
+		return 0, b.ItfErr
+	}
+
+	size, err := pkg.ToBytesSize(b.IterVal)
+	if err != nil {
+		return 0, itferr.NewConvFailedX(fmt.Sprintf("%s#ToBytesSize", b.Class), "", err)
+	}
+	return size, nil
+}
+
+func (b *BaseItfImpl) ToPercent() (float64, error) {
+	if b.ItfErr != nil {
+		return 0, b.ItfErr
+	}
+
+	f, err := pkg.ToPercent(b.IterVal)
+	if err != nil {
+		return 0, itferr.NewConvFailedX(fmt.Sprintf("%s#ToPercent", b.Class), "", err)
+	}
+	return f, nil
+}
+
+func (b *BaseItfImpl) ToBigInt() (*big.Int, error) {
+	if b.ItfErr != nil {
+		return nil, b.ItfErr
+	}
+
+	i, err := pkg.ToBigInt(b.IterVal)
+	if err != nil {
+		return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToBigInt", b.Class), "", err)
+	}
+	return i, nil
+}
+
+func (b *BaseItfImpl) ToBigFloat() (*big.Float, error) {
+	if b.ItfErr != nil {
+		return nil, b.ItfErr
+	}
+
+	f, err := pkg.ToBigFloat(b.IterVal)
+	if err != nil {
+		return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToBigFloat", b.Class), "", err)
+	}
+	return f, nil
+}
+
+func (b *BaseItfImpl) ToBigRat() (*big.Rat, error) {
+	if b.ItfErr != nil {
+		return nil, b.ItfErr
+	}
+
+	r, err := pkg.ToBigRat(b.IterVal)
+	if err != nil {
+		return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToBigRat", b.Class), "", err)
+	}
+	return r, nil
+}
+
+func (b *BaseItfImpl) ToDecimalStr(scale int) (string, error) {
+	if b.ItfErr != nil {
+		return "", b.ItfErr
+	}
+
+	s, err := pkg.ToDecimalStr(b.IterVal, scale)
+	if err != nil {
+		return "", itferr.NewConvFailedX(fmt.Sprintf("%s#ToDecimalStr", b.Class), "", err)
+	}
+	return s, nil
 }
 
 // ToMapType ----------------------------------------------------------------------------------------
@@ -338,6 +475,10 @@ func toMap(ctx context.Context, m interface{}) (map[string]interface{}, error) {
 		}
 	}
 
+	if pkg.IsStruct(m) {
+		return pkg.StructToMap(m)
+	}
+
 	if v, ok := m.(map[interface{}]interface{}); ok {
 		result := make(map[string]interface{}, len(v))
 		for k, v := range v {
@@ -396,7 +537,7 @@ func (b *BaseItfImpl) ToMapInt() (map[int]interface{}, error) {
 	if cvtOk {
 		result := make(map[int]interface{}, len(mapStrItf))
 		for kk, vv := range mapStrItf {
-			if cnvK, cnvKErr := pkg.ToInt64(kk); cnvKErr == nil {
+			if cnvK, cnvKErr := b.toIntN(kk, 0); cnvKErr == nil {
 				result[int(cnvK)] = vv
 			} else if !conf.CONF.SkipCvtFailForToMapType {
 				return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToMapInt", b.Class), "map key not int type", cnvKErr)
@@ -420,8 +561,11 @@ func (b *BaseItfImpl) ToMapInt() (map[int]interface{}, error) {
 			continue
 		}
 
-		cnvK, cnvKErr := pkg.ToInt64(rfK.Interface())
+		cnvK, cnvKErr := b.toIntN(rfK.Interface(), 0)
 		if cnvKErr != nil {
+			if !conf.CONF.SkipCvtFailForToMapType {
+				return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToMapInt", b.Class), "map key convert failed", cnvKErr)
+			}
 			logx.CtxWarn(b.Ctx, "ToMapInt convert err:%v", cnvKErr)
 			continue
 		}
@@ -460,7 +604,7 @@ func (b *BaseItfImpl) ToMapInt64() (map[int64]interface{}, error) {
 	if cvtOk {
 		result := make(map[int64]interface{}, len(mapStrItf))
 		for kk, vv := range mapStrItf {
-			if cnvK, cnvKErr := pkg.ToInt64(kk); cnvKErr == nil {
+			if cnvK, cnvKErr := b.toIntN(kk, 64); cnvKErr == nil {
 				result[cnvK] = vv
 			} else if !conf.CONF.SkipCvtFailForToMapType {
 				return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToMapInt64", b.Class), "map key not int64 type", cnvKErr)
@@ -484,8 +628,11 @@ func (b *BaseItfImpl) ToMapInt64() (map[int64]interface{}, error) {
 			continue
 		}
 
-		cnvK, cnvKErr := pkg.ToInt64(rfK.Interface())
+		cnvK, cnvKErr := b.toIntN(rfK.Interface(), 64)
 		if cnvKErr != nil {
+			if !conf.CONF.SkipCvtFailForToMapType {
+				return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToMapInt64", b.Class), "map key convert failed", cnvKErr)
+			}
 			logx.CtxWarn(b.Ctx, "ToMapInt64 convert err:%v", cnvKErr)
 			continue
 		}
@@ -524,7 +671,7 @@ func (b *BaseItfImpl) ToMapInt32() (map[int32]interface{}, error) {
 	if cvtOk {
 		result := make(map[int32]interface{}, len(mapStrItf))
 		for kk, vv := range mapStrItf {
-			if cnvK, cnvKErr := pkg.ToInt64(kk); cnvKErr == nil {
+			if cnvK, cnvKErr := b.toIntN(kk, 32); cnvKErr == nil {
 				result[int32(cnvK)] = vv
 			} else if !conf.CONF.SkipCvtFailForToMapType {
 				return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToMapInt32", b.Class), "map key not int32 type", cnvKErr)
@@ -548,8 +695,11 @@ func (b *BaseItfImpl) ToMapInt32() (map[int32]interface{}, error) {
 			continue
 		}
 
-		cnvK, cnvKErr := pkg.ToInt64(rfK.Interface())
+		cnvK, cnvKErr := b.toIntN(rfK.Interface(), 32)
 		if cnvKErr != nil {
+			if !conf.CONF.SkipCvtFailForToMapType {
+				return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToMapInt32", b.Class), "map key convert failed", cnvKErr)
+			}
 			logx.CtxWarn(b.Ctx, "ToMapInt32 convert err:%v", cnvKErr)
 			continue
 		}
@@ -589,7 +739,7 @@ func (b *BaseItfImpl) ToMapUint() (map[uint]interface{}, error) {
 	if cvtOk {
 		result := make(map[uint]interface{}, len(mapStrItf))
 		for kk, vv := range mapStrItf {
-			if cnvK, cnvKErr := pkg.ToInt64(kk); cnvKErr == nil {
+			if cnvK, cnvKErr := b.toUintN(kk, 0); cnvKErr == nil {
 				result[uint(cnvK)] = vv
 			} else if !conf.CONF.SkipCvtFailForToMapType {
 				return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToMapUint", b.Class), "map key not uint type", cnvKErr)
@@ -613,8 +763,11 @@ func (b *BaseItfImpl) ToMapUint() (map[uint]interface{}, error) {
 			continue
 		}
 
-		cnvK, cnvKErr := pkg.ToInt64(rfK.Interface())
+		cnvK, cnvKErr := b.toUintN(rfK.Interface(), 0)
 		if cnvKErr != nil {
+			if !conf.CONF.SkipCvtFailForToMapType {
+				return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToMapUint", b.Class), "map key convert failed", cnvKErr)
+			}
 			logx.CtxWarn(b.Ctx, "ToMapUint convert err:%v", cnvKErr)
 			continue
 		}
@@ -653,7 +806,7 @@ func (b *BaseItfImpl) ToMapUint64() (map[uint64]interface{}, error) {
 	if cvtOk {
 		result := make(map[uint64]interface{}, len(mapStrItf))
 		for kk, vv := range mapStrItf {
-			if cnvK, cnvKErr := pkg.ToInt64(kk); cnvKErr == nil {
+			if cnvK, cnvKErr := b.toUintN(kk, 64); cnvKErr == nil {
 				result[uint64(cnvK)] = vv
 			} else if !conf.CONF.SkipCvtFailForToMapType {
 				return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToMapUint64", b.Class), "map key not uint64 type", cnvKErr)
@@ -677,8 +830,11 @@ func (b *BaseItfImpl) ToMapUint64() (map[uint64]interface{}, error) {
 			continue
 		}
 
-		cnvK, cnvKErr := pkg.ToInt64(rfK.Interface())
+		cnvK, cnvKErr := b.toUintN(rfK.Interface(), 64)
 		if cnvKErr != nil {
+			if !conf.CONF.SkipCvtFailForToMapType {
+				return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToMapUint64", b.Class), "map key convert failed", cnvKErr)
+			}
 			logx.CtxWarn(b.Ctx, "ToMapUint64 convert err:%v", cnvKErr)
 			continue
 		}
@@ -718,7 +874,7 @@ func (b *BaseItfImpl) ToMapUint32() (map[uint32]interface{}, error) {
 	if cvtOk {
 		result := make(map[uint32]interface{}, len(mapStrItf))
 		for kk, vv := range mapStrItf {
-			if cnvK, cnvKErr := pkg.ToInt64(kk); cnvKErr == nil {
+			if cnvK, cnvKErr := b.toUintN(kk, 32); cnvKErr == nil {
 				result[uint32(cnvK)] = vv
 			} else if !conf.CONF.SkipCvtFailForToMapType {
 				return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToMapUint32", b.Class), "map key not uint32 type", cnvKErr)
@@ -742,8 +898,11 @@ func (b *BaseItfImpl) ToMapUint32() (map[uint32]interface{}, error) {
 			continue
 		}
 
-		cnvK, cnvKErr := pkg.ToInt64(rfK.Interface())
+		cnvK, cnvKErr := b.toUintN(rfK.Interface(), 32)
 		if cnvKErr != nil {
+			if !conf.CONF.SkipCvtFailForToMapType {
+				return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToMapUint32", b.Class), "map key convert failed", cnvKErr)
+			}
 			logx.CtxWarn(b.Ctx, "ToMapUint32 convert err:%v", cnvKErr)
 			continue
 		}
@@ -783,7 +942,7 @@ func (b *BaseItfImpl) ToMapFloat32() (map[float32]interface{}, error) {
 	if cvtOk {
 		result := make(map[float32]interface{}, len(mapStrItf))
 		for kk, vv := range mapStrItf {
-			if cnvK, cnvKErr := pkg.ToFloat32(kk); cnvKErr == nil {
+			if cnvK, cnvKErr := b.toFloat32(kk); cnvKErr == nil {
 				result[cnvK] = vv
 			} else if !conf.CONF.SkipCvtFailForToMapType {
 				return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToMapFloat32", b.Class), "map key not float32 type", cnvKErr)
@@ -807,8 +966,11 @@ func (b *BaseItfImpl) ToMapFloat32() (map[float32]interface{}, error) {
 			continue
 		}
 
-		cnvK, cnvKErr := pkg.ToFloat32(rfK.Interface())
+		cnvK, cnvKErr := b.toFloat32(rfK.Interface())
 		if cnvKErr != nil {
+			if !conf.CONF.SkipCvtFailForToMapType {
+				return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToMapFloat32", b.Class), "map key convert failed", cnvKErr)
+			}
 			logx.CtxWarn(b.Ctx, "ToMapFloat32 convert err:%v", cnvKErr)
 			continue
 		}
@@ -848,7 +1010,7 @@ func (b *BaseItfImpl) ToMapFloat64() (map[float64]interface{}, error) {
 	if cvtOk {
 		result := make(map[float64]interface{}, len(mapStrItf))
 		for kk, vv := range mapStrItf {
-			if cnvK, cnvKErr := pkg.ToFloat64(kk); cnvKErr == nil {
+			if cnvK, cnvKErr := b.toFloatN(kk, 64); cnvKErr == nil {
 				result[cnvK] = vv
 			} else if !conf.CONF.SkipCvtFailForToMapType {
 				return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToMapFloat64", b.Class), "map key not float64 type", cnvKErr)
@@ -872,8 +1034,11 @@ func (b *BaseItfImpl) ToMapFloat64() (map[float64]interface{}, error) {
 			continue
 		}
 
-		cnvK, cnvKErr := pkg.ToFloat64(rfK.Interface())
+		cnvK, cnvKErr := b.toFloatN(rfK.Interface(), 64)
 		if cnvKErr != nil {
+			if !conf.CONF.SkipCvtFailForToMapType {
+				return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToMapFloat64", b.Class), "map key convert failed", cnvKErr)
+			}
 			logx.CtxWarn(b.Ctx, "ToMapFloat64 convert err:%v", cnvKErr)
 			continue
 		}
@@ -1036,10 +1201,10 @@ func (b *BaseItfImpl) ToMapIntToInt() (map[int]int, error) {
 	if cvtOk {
 		result := make(map[int]int, len(mapStrItf))
 		for kk, vv := range mapStrItf {
-			cnvK, cnvKErr := pkg.ToInt64(kk)
-			cnvV, cnvVErr := pkg.ToInt64(vv)
+			cnvK, cnvKErr := b.toIntN(kk, 0)
+			cnvV, cnvVErr := b.toIntN(vv, 0)
 			if (cnvKErr != nil || cnvVErr != nil) && !conf.CONF.SkipCvtFailForToMapType {
-				return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToMapIntToInt", b.Class), "map key not int type", cnvKErr)
+				return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToMapIntToInt", b.Class), "map key not int type", firstErr(cnvKErr, cnvVErr))
 			}
 			if cnvKErr == nil && cnvVErr == nil {
 				result[int(cnvK)] = int(cnvV)
@@ -1051,9 +1216,12 @@ func (b *BaseItfImpl) ToMapIntToInt() (map[int]int, error) {
 	if v, ok := b.IterVal.(map[interface{}]interface{}); ok {
 		result := make(map[int]int, len(v))
 		for kk, vv := range v {
-			cnvK, cnvKErr := pkg.ToInt64(kk)
-			cnvV, cnvVErr := pkg.ToInt64(vv)
+			cnvK, cnvKErr := b.toIntN(kk, 0)
+			cnvV, cnvVErr := b.toIntN(vv, 0)
 			if cnvKErr != nil || cnvVErr != nil {
+				if !conf.CONF.SkipCvtFailForToMapType {
+					return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToMapIntToInt", b.Class), "map key value convert failed", firstErr(cnvKErr, cnvVErr))
+				}
 				logx.CtxWarn(b.Ctx, "ToMapIntToInt convert err:%v:%v", cnvKErr, cnvVErr)
 				continue
 			}
@@ -1083,9 +1251,12 @@ func (b *BaseItfImpl) ToMapIntToInt() (map[int]int, error) {
 			continue
 		}
 
-		cnvK, cnvKErr := pkg.ToInt64(rfK.Interface())
-		cnvV, cnvVErr := pkg.ToInt64(mpV.Interface())
+		cnvK, cnvKErr := b.toIntN(rfK.Interface(), 0)
+		cnvV, cnvVErr := b.toIntN(mpV.Interface(), 0)
 		if cnvKErr != nil || cnvVErr != nil {
+			if !conf.CONF.SkipCvtFailForToMapType {
+				return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToMapIntToInt", b.Class), "map key value convert failed", firstErr(cnvKErr, cnvVErr))
+			}
 			logx.CtxWarn(b.Ctx, "ToMapIntToInt convert err:%v:%v", cnvKErr, cnvVErr)
 			continue
 		}
@@ -1124,10 +1295,10 @@ func (b *BaseItfImpl) ToMapInt64ToInt64() (map[int64]int64, error) {
 	if cvtOk {
 		result := make(map[int64]int64, len(mapStrItf))
 		for kk, vv := range mapStrItf {
-			cnvK, cnvKErr := pkg.ToInt64(kk)
-			cnvV, cnvVErr := pkg.ToInt64(vv)
+			cnvK, cnvKErr := b.toIntN(kk, 64)
+			cnvV, cnvVErr := b.toIntN(vv, 64)
 			if (cnvKErr != nil || cnvVErr != nil) && !conf.CONF.SkipCvtFailForToMapType {
-				return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToMapInt64ToInt64", b.Class), "map key not int64 type", cnvKErr)
+				return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToMapInt64ToInt64", b.Class), "map key not int64 type", firstErr(cnvKErr, cnvVErr))
 			}
 			if cnvKErr == nil && cnvVErr == nil {
 				result[cnvK] = cnvV
@@ -1139,9 +1310,12 @@ func (b *BaseItfImpl) ToMapInt64ToInt64() (map[int64]int64, error) {
 	if v, ok := b.IterVal.(map[interface{}]interface{}); ok {
 		result := make(map[int64]int64, len(v))
 		for kk, vv := range v {
-			cnvK, cnvKErr := pkg.ToInt64(kk)
-			cnvV, cnvVErr := pkg.ToInt64(vv)
+			cnvK, cnvKErr := b.toIntN(kk, 64)
+			cnvV, cnvVErr := b.toIntN(vv, 64)
 			if cnvKErr != nil || cnvVErr != nil {
+				if !conf.CONF.SkipCvtFailForToMapType {
+					return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToMapInt64ToInt64", b.Class), "map key value convert failed", firstErr(cnvKErr, cnvVErr))
+				}
 				logx.CtxWarn(b.Ctx, "ToMapInt64ToInt64 convert err:%v:%v", cnvKErr, cnvVErr)
 				continue
 			}
@@ -1171,9 +1345,12 @@ func (b *BaseItfImpl) ToMapInt64ToInt64() (map[int64]int64, error) {
 			continue
 		}
 
-		cnvK, cnvKErr := pkg.ToInt64(rfK.Interface())
-		cnvV, cnvVErr := pkg.ToInt64(mpV.Interface())
+		cnvK, cnvKErr := b.toIntN(rfK.Interface(), 64)
+		cnvV, cnvVErr := b.toIntN(mpV.Interface(), 64)
 		if cnvKErr != nil || cnvVErr != nil {
+			if !conf.CONF.SkipCvtFailForToMapType {
+				return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToMapInt64ToInt64", b.Class), "map key value convert failed", firstErr(cnvKErr, cnvVErr))
+			}
 			logx.CtxWarn(b.Ctx, "ToMapInt64ToInt64 convert err:%v:%v", cnvKErr, cnvVErr)
 			continue
 		}
@@ -1212,10 +1389,10 @@ func (b *BaseItfImpl) ToMapFloat64ToFloat64() (map[float64]float64, error) {
 	if cvtOk {
 		result := make(map[float64]float64, len(mapStrItf))
 		for kk, vv := range mapStrItf {
-			cnvK, cnvKErr := pkg.ToFloat64(kk)
-			cnvV, cnvVErr := pkg.ToFloat64(vv)
+			cnvK, cnvKErr := b.toFloatN(kk, 64)
+			cnvV, cnvVErr := b.toFloatN(vv, 64)
 			if (cnvKErr != nil || cnvVErr != nil) && !conf.CONF.SkipCvtFailForToMapType {
-				return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToMapFloat64ToFloat64", b.Class), "map key not float64 type", cnvKErr)
+				return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToMapFloat64ToFloat64", b.Class), "map key not float64 type", firstErr(cnvKErr, cnvVErr))
 			}
 			if cnvKErr == nil && cnvVErr == nil {
 				result[cnvK] = cnvV
@@ -1227,9 +1404,12 @@ func (b *BaseItfImpl) ToMapFloat64ToFloat64() (map[float64]float64, error) {
 	if v, ok := b.IterVal.(map[interface{}]interface{}); ok {
 		result := make(map[float64]float64, len(v))
 		for kk, vv := range v {
-			cnvK, cnvKErr := pkg.ToFloat64(kk)
-			cnvV, cnvVErr := pkg.ToFloat64(vv)
+			cnvK, cnvKErr := b.toFloatN(kk, 64)
+			cnvV, cnvVErr := b.toFloatN(vv, 64)
 			if cnvKErr != nil || cnvVErr != nil {
+				if !conf.CONF.SkipCvtFailForToMapType {
+					return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToMapFloat64ToFloat64", b.Class), "map key value convert failed", firstErr(cnvKErr, cnvVErr))
+				}
 				logx.CtxWarn(b.Ctx, "ToMapFloat64ToFloat64 convert err:%v:%v", cnvKErr, cnvVErr)
 				continue
 			}
@@ -1259,9 +1439,12 @@ func (b *BaseItfImpl) ToMapFloat64ToFloat64() (map[float64]float64, error) {
 			continue
 		}
 
-		cnvK, cnvKErr := pkg.ToFloat64(rfK.Interface())
-		cnvV, cnvVErr := pkg.ToFloat64(mpV.Interface())
+		cnvK, cnvKErr := b.toFloatN(rfK.Interface(), 64)
+		cnvV, cnvVErr := b.toFloatN(mpV.Interface(), 64)
 		if cnvKErr != nil || cnvVErr != nil {
+			if !conf.CONF.SkipCvtFailForToMapType {
+				return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToMapFloat64ToFloat64", b.Class), "map key value convert failed", firstErr(cnvKErr, cnvVErr))
+			}
 			logx.CtxWarn(b.Ctx, "ToMapFloat64ToFloat64 convert err:%v:%v", cnvKErr, cnvVErr)
 			continue
 		}
@@ -1300,10 +1483,10 @@ func (b *BaseItfImpl) ToMapFloat32ToFloat32() (map[float32]float32, error) {
 	if cvtOk {
 		result := make(map[float32]float32, len(mapStrItf))
 		for kk, vv := range mapStrItf {
-			cnvK, cnvKErr := pkg.ToFloat32(kk)
-			cnvV, cnvVErr := pkg.ToFloat32(vv)
+			cnvK, cnvKErr := b.toFloat32(kk)
+			cnvV, cnvVErr := b.toFloat32(vv)
 			if (cnvKErr != nil || cnvVErr != nil) && !conf.CONF.SkipCvtFailForToMapType {
-				return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToMapFloat32ToFloat32", b.Class), "map key not float32 type", cnvKErr)
+				return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToMapFloat32ToFloat32", b.Class), "map key not float32 type", firstErr(cnvKErr, cnvVErr))
 			}
 			if cnvKErr == nil && cnvVErr == nil {
 				result[cnvK] = cnvV
@@ -1315,9 +1498,12 @@ func (b *BaseItfImpl) ToMapFloat32ToFloat32() (map[float32]float32, error) {
 	if v, ok := b.IterVal.(map[interface{}]interface{}); ok {
 		result := make(map[float32]float32, len(v))
 		for kk, vv := range v {
-			cnvK, cnvKErr := pkg.ToFloat32(kk)
-			cnvV, cnvVErr := pkg.ToFloat32(vv)
+			cnvK, cnvKErr := b.toFloat32(kk)
+			cnvV, cnvVErr := b.toFloat32(vv)
 			if cnvKErr != nil || cnvVErr != nil {
+				if !conf.CONF.SkipCvtFailForToMapType {
+					return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToMapFloat32ToFloat32", b.Class), "map key value convert failed", firstErr(cnvKErr, cnvVErr))
+				}
 				logx.CtxWarn(b.Ctx, "ToMapFloat32ToFloat32 convert err:%v:%v", cnvKErr, cnvVErr)
 				continue
 			}
@@ -1347,9 +1533,12 @@ func (b *BaseItfImpl) ToMapFloat32ToFloat32() (map[float32]float32, error) {
 			continue
 		}
 
-		cnvK, cnvKErr := pkg.ToFloat32(rfK.Interface())
-		cnvV, cnvVErr := pkg.ToFloat32(mpV.Interface())
+		cnvK, cnvKErr := b.toFloat32(rfK.Interface())
+		cnvV, cnvVErr := b.toFloat32(mpV.Interface())
 		if cnvKErr != nil || cnvVErr != nil {
+			if !conf.CONF.SkipCvtFailForToMapType {
+				return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToMapFloat32ToFloat32", b.Class), "map key value convert failed", firstErr(cnvKErr, cnvVErr))
+			}
 			logx.CtxWarn(b.Ctx, "ToMapFloat32ToFloat32 convert err:%v:%v", cnvKErr, cnvVErr)
 			continue
 		}
@@ -1548,7 +1737,7 @@ func (b *BaseItfImpl) ToListInt() ([]int, error) {
 	if cvtOk {
 		listInt := make([]int, 0, len(listItf))
 		for _, v := range listItf {
-			if vint64, cnvKErr := pkg.ToInt64(v); cnvKErr == nil {
+			if vint64, cnvKErr := b.toIntN(v, 0); cnvKErr == nil {
 				listInt = append(listInt, int(vint64))
 			} else if !conf.CONF.SkipCvtFailForToArrayType {
 				return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToListInt", b.Class), "list val not int type", cnvKErr)
@@ -1571,9 +1760,14 @@ func (b *BaseItfImpl) ToListInt() ([]int, error) {
 			}
 			return nil, itferr.NewConvFailed(fmt.Sprintf("%s#ToListStr", b.Class))
 		}
-		if iv, ok := pkg.ToInt64(ele.Interface()); ok == nil {
-			resultList = append(resultList, int(iv))
+		iv, cnvErr := b.toIntN(ele.Interface(), 0)
+		if cnvErr != nil {
+			if conf.CONF.SkipCvtFailForToArrayType {
+				continue
+			}
+			return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToListInt(%d)", b.Class, i), "list val convert failed", cnvErr)
 		}
+		resultList = append(resultList, int(iv))
 	}
 
 	if len(resultList) != rv.Len() && !conf.CONF.SkipCvtFailForToArrayType {
@@ -1608,7 +1802,7 @@ func (b *BaseItfImpl) ToListInt32() ([]int32, error) {
 	if cvtOk {
 		listInt := make([]int32, 0, len(listItf))
 		for _, v := range listItf {
-			if vint64, cnvKErr := pkg.ToInt64(v); cnvKErr == nil {
+			if vint64, cnvKErr := b.toIntN(v, 32); cnvKErr == nil {
 				listInt = append(listInt, int32(vint64))
 			} else if !conf.CONF.SkipCvtFailForToArrayType {
 				return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToListInt32", b.Class), "list val not int32 type", cnvKErr)
@@ -1631,9 +1825,14 @@ func (b *BaseItfImpl) ToListInt32() ([]int32, error) {
 			}
 			return nil, itferr.NewConvFailed(fmt.Sprintf("%s#ToListInt32", b.Class))
 		}
-		if iv, ok := pkg.ToInt64(ele.Interface()); ok == nil {
-			resultList = append(resultList, int32(iv))
+		iv, cnvErr := b.toIntN(ele.Interface(), 32)
+		if cnvErr != nil {
+			if conf.CONF.SkipCvtFailForToArrayType {
+				continue
+			}
+			return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToListInt32(%d)", b.Class, i), "list val convert failed", cnvErr)
 		}
+		resultList = append(resultList, int32(iv))
 	}
 
 	if len(resultList) != rv.Len() && !conf.CONF.SkipCvtFailForToArrayType {
@@ -1681,7 +1880,7 @@ func (b *BaseItfImpl) ToListInt64() ([]int64, error) {
 	if cvtOk {
 		listInt := make([]int64, 0, len(listItf))
 		for _, v := range listItf {
-			if vint64, cnvKErr := pkg.ToInt64(v); cnvKErr == nil {
+			if vint64, cnvKErr := b.toIntN(v, 64); cnvKErr == nil {
 				listInt = append(listInt, vint64)
 			} else if !conf.CONF.SkipCvtFailForToArrayType {
 				return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToListInt64", b.Class), "list val not int64 type", cnvKErr)
@@ -1704,9 +1903,14 @@ func (b *BaseItfImpl) ToListInt64() ([]int64, error) {
 			}
 			return nil, itferr.NewConvFailed(fmt.Sprintf("%s#ToListInt64", b.Class))
 		}
-		if iv, ok := pkg.ToInt64(ele.Interface()); ok == nil {
-			resultList = append(resultList, iv)
+		iv, cnvErr := b.toIntN(ele.Interface(), 64)
+		if cnvErr != nil {
+			if conf.CONF.SkipCvtFailForToArrayType {
+				continue
+			}
+			return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToListInt64(%d)", b.Class, i), "list val convert failed", cnvErr)
 		}
+		resultList = append(resultList, iv)
 	}
 
 	if len(resultList) != rv.Len() && !conf.CONF.SkipCvtFailForToArrayType {
@@ -1741,7 +1945,7 @@ func (b *BaseItfImpl) ToListUint() ([]uint, error) {
 	if cvtOk {
 		listInt := make([]uint, 0, len(listItf))
 		for _, v := range listItf {
-			if vint64, cnvKErr := pkg.ToInt64(v); cnvKErr == nil {
+			if vint64, cnvKErr := b.toUintN(v, 0); cnvKErr == nil {
 				listInt = append(listInt, uint(vint64))
 			} else if !conf.CONF.SkipCvtFailForToArrayType {
 				return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToListUint", b.Class), "list val not uint type", cnvKErr)
@@ -1764,9 +1968,14 @@ func (b *BaseItfImpl) ToListUint() ([]uint, error) {
 			}
 			return nil, itferr.NewConvFailed(fmt.Sprintf("%s#ToListUInt", b.Class))
 		}
-		if iv, ok := pkg.ToInt64(ele.Interface()); ok == nil {
-			resultList = append(resultList, uint(iv))
+		iv, cnvErr := b.toUintN(ele.Interface(), 0)
+		if cnvErr != nil {
+			if conf.CONF.SkipCvtFailForToArrayType {
+				continue
+			}
+			return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToListUint(%d)", b.Class, i), "list val convert failed", cnvErr)
 		}
+		resultList = append(resultList, uint(iv))
 	}
 
 	if len(resultList) != rv.Len() && !conf.CONF.SkipCvtFailForToArrayType {
@@ -1801,7 +2010,7 @@ func (b *BaseItfImpl) ToListUint64() ([]uint64, error) {
 	if cvtOk {
 		listInt := make([]uint64, 0, len(listItf))
 		for _, v := range listItf {
-			if vint64, cnvKErr := pkg.ToInt64(v); cnvKErr == nil {
+			if vint64, cnvKErr := b.toUintN(v, 64); cnvKErr == nil {
 				listInt = append(listInt, uint64(vint64))
 			} else if !conf.CONF.SkipCvtFailForToArrayType {
 				return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToListUint64", b.Class), "list val not uint64 type", cnvKErr)
@@ -1824,9 +2033,14 @@ func (b *BaseItfImpl) ToListUint64() ([]uint64, error) {
 			}
 			return nil, itferr.NewConvFailed(fmt.Sprintf("%s#ToListUint64", b.Class))
 		}
-		if iv, ok := pkg.ToInt64(ele.Interface()); ok == nil {
-			resultList = append(resultList, uint64(iv))
+		iv, cnvErr := b.toUintN(ele.Interface(), 64)
+		if cnvErr != nil {
+			if conf.CONF.SkipCvtFailForToArrayType {
+				continue
+			}
+			return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToListUint64(%d)", b.Class, i), "list val convert failed", cnvErr)
 		}
+		resultList = append(resultList, uint64(iv))
 	}
 
 	if len(resultList) != rv.Len() && !conf.CONF.SkipCvtFailForToArrayType {
@@ -1861,7 +2075,7 @@ func (b *BaseItfImpl) ToListUint32() ([]uint32, error) {
 	if cvtOk {
 		listInt := make([]uint32, 0, len(listItf))
 		for _, v := range listItf {
-			if vint64, cnvKErr := pkg.ToInt64(v); cnvKErr == nil {
+			if vint64, cnvKErr := b.toUintN(v, 32); cnvKErr == nil {
 				listInt = append(listInt, uint32(vint64))
 			} else if !conf.CONF.SkipCvtFailForToArrayType {
 				return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToListUint32", b.Class), "list val not uint32 type", cnvKErr)
@@ -1884,9 +2098,14 @@ func (b *BaseItfImpl) ToListUint32() ([]uint32, error) {
 			}
 			return nil, itferr.NewConvFailed(fmt.Sprintf("%s#ToListUint32", b.Class))
 		}
-		if iv, ok := pkg.ToInt64(ele.Interface()); ok == nil {
-			resultList = append(resultList, uint32(iv))
+		iv, cnvErr := b.toUintN(ele.Interface(), 32)
+		if cnvErr != nil {
+			if conf.CONF.SkipCvtFailForToArrayType {
+				continue
+			}
+			return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToListUint32(%d)", b.Class, i), "list val convert failed", cnvErr)
 		}
+		resultList = append(resultList, uint32(iv))
 	}
 
 	if len(resultList) != rv.Len() && !conf.CONF.SkipCvtFailForToArrayType {
@@ -1921,7 +2140,7 @@ func (b *BaseItfImpl) ToListFloat32() ([]float32, error) {
 	if cvtOk {
 		listf32 := make([]float32, 0, len(listItf))
 		for _, v := range listItf {
-			if vf32, cnvKErr := pkg.ToFloat32(v); cnvKErr == nil {
+			if vf32, cnvKErr := b.toFloat32(v); cnvKErr == nil {
 				listf32 = append(listf32, vf32)
 			} else if !conf.CONF.SkipCvtFailForToArrayType {
 				return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToListFloat32", b.Class), "list val not float32 type", cnvKErr)
@@ -1944,9 +2163,14 @@ func (b *BaseItfImpl) ToListFloat32() ([]float32, error) {
 			}
 			return nil, itferr.NewConvFailed(fmt.Sprintf("%s#ToListFloat32", b.Class))
 		}
-		if iv, ok := pkg.ToFloat32(ele.Interface()); ok == nil {
-			resultList = append(resultList, iv)
+		iv, cnvErr := b.toFloat32(ele.Interface())
+		if cnvErr != nil {
+			if conf.CONF.SkipCvtFailForToArrayType {
+				continue
+			}
+			return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToListFloat32(%d)", b.Class, i), "list val convert failed", cnvErr)
 		}
+		resultList = append(resultList, iv)
 	}
 
 	if len(resultList) != rv.Len() && !conf.CONF.SkipCvtFailForToArrayType {
@@ -1981,7 +2205,7 @@ func (b *BaseItfImpl) ToListFloat64() ([]float64, error) {
 	if cvtOk {
 		listf64 := make([]float64, 0, len(listItf))
 		for _, v := range listItf {
-			if vf64, cnvKErr := pkg.ToFloat64(v); cnvKErr == nil {
+			if vf64, cnvKErr := b.toFloatN(v, 64); cnvKErr == nil {
 				listf64 = append(listf64, vf64)
 			} else if !conf.CONF.SkipCvtFailForToArrayType {
 				return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToListFloat64", b.Class), "list val not float64 type", cnvKErr)
@@ -2004,9 +2228,14 @@ func (b *BaseItfImpl) ToListFloat64() ([]float64, error) {
 			}
 			return nil, itferr.NewConvFailed(fmt.Sprintf("%s#ToListFloat64", b.Class))
 		}
-		if iv, ok := pkg.ToFloat64(ele.Interface()); ok == nil {
-			resultList = append(resultList, iv)
+		iv, cnvErr := b.toFloatN(ele.Interface(), 64)
+		if cnvErr != nil {
+			if conf.CONF.SkipCvtFailForToArrayType {
+				continue
+			}
+			return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToListFloat64(%d)", b.Class, i), "list val convert failed", cnvErr)
 		}
+		resultList = append(resultList, iv)
 	}
 
 	if len(resultList) != rv.Len() && !conf.CONF.SkipCvtFailForToArrayType {
@@ -2040,12 +2269,18 @@ func (b *BaseItfImpl) ToListBool() ([]bool, error) {
 	}
 	if cvtOk {
 		listb := make([]bool, 0, len(listItf))
-		for _, v := range listItf {
-			if bl, ok := v.(bool); ok {
-				listb = append(listb, bl)
-			} else if i, cnvKErr := pkg.ToInt64(v); cnvKErr == nil {
-				listb = append(listb, i != 0)
+		for i, v := range listItf {
+			bl, cvtErr := pkg.ToBool(v)
+			if cvtErr != nil {
+				if conf.CONF.SkipCvtFailForToArrayType {
+					continue
+				}
+				return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToListBool(%d)", b.Class, i), "list val not bool type", cvtErr)
 			}
+			listb = append(listb, bl)
+		}
+		if len(listb) != len(listItf) {
+			logx.CtxWarn(b.Ctx, "ToListBool %d convert failed", len(listItf)-len(listb))
 		}
 		return listb, nil
 	}
@@ -2053,30 +2288,71 @@ func (b *BaseItfImpl) ToListBool() ([]bool, error) {
 	return nil, itferr.NewConvFailed(fmt.Sprintf("%s#ToListBool", b.Class))
 }
 
+func (b *BaseItfImpl) ToListTime(layouts ...string) ([]time.Time, error) {
+	if b.ItfErr != nil {
+		return nil, b.ItfErr
+	}
+
+	if v, ok := b.IterVal.([]time.Time); ok {
+		return v, nil
+	}
+
+	listItf, err := b.ToList()
+	if err != nil {
+		return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToListTime", b.Class), "val is not list", err)
+	}
+	result := make([]time.Time, 0, len(listItf))
+	for i, v := range listItf {
+		t, cvtErr := pkg.ToTime(v, time.Local, layouts...)
+		if cvtErr != nil {
+			if conf.CONF.SkipCvtFailForToArrayType {
+				continue
+			}
+			return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToListTime(%d)", b.Class, i), "list val not time type", cvtErr)
+		}
+		result = append(result, t)
+	}
+	if len(result) != len(listItf) {
+		logx.CtxWarn(b.Ctx, "ToListTime %d convert failed", len(listItf)-len(result))
+	}
+	return result, nil
+}
+
 // ToObjectType ----------------------------------------------------------------------------------------
-func (b *BaseItfImpl) ToStruct(stc interface{}) (interface{}, error) {
+func (b *BaseItfImpl) ToStruct(stc interface{}, opt ...api.StructOpt) (interface{}, error) {
 	if b.ItfErr != nil {
 		return nil, b.ItfErr
 	}
+	tagName := structTagName(opt)
 
 	// 1. 字符串转换
-	if isJson, js := pkg.JsonChecker(b.IterVal); isJson {
-		if obj, err := pkg.JsonLoadsObj(js, stc); err == nil {
-			return obj, nil
-		} else {
+	if isJson, _ := pkg.JsonChecker(b.IterVal); isJson {
+		obj, err := decodeToStruct(b.IterVal, stc, tagName)
+		if err != nil {
 			return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToStruct", b.Class), "json un-match struct", err)
 		}
+		return obj, nil
 	}
 
 	// 2. 类型原本就一致
 	rfSrc, rfDst := pkg.Interpret(b.IterVal), pkg.Interpret(stc)
-	if reflect.TypeOf(rfSrc).Kind() == reflect.TypeOf(rfDst).Kind() {
+	srcType, dstType := reflect.TypeOf(rfSrc), reflect.TypeOf(rfDst)
+	if srcType == nil || dstType == nil {
+		return nil, itferr.NewConvFailed(fmt.Sprintf("%s#ToStruct", b.Class))
+	}
+	if srcType == dstType {
+		if rfOut := reflect.ValueOf(stc); rfOut.Kind() == reflect.Ptr && rfOut.Elem().CanSet() && rfOut.Elem().Type() == srcType {
+			rfOut.Elem().Set(reflect.ValueOf(rfSrc))
+		}
+		return b.IterVal, nil
+	}
+	if srcType.Kind() == dstType.Kind() && srcType.Kind() != reflect.Struct {
 		return b.IterVal, nil
 	}
 
-	//3. map转换到obj
-	if reflect.TypeOf(rfSrc).Kind() == reflect.Map {
-		dstStruct, err := pkg.MapToStruct(b.IterVal, stc)
+	//3. map或其他struct转换到obj
+	if srcType.Kind() == reflect.Map || srcType.Kind() == reflect.Struct {
+		dstStruct, err := decodeToStruct(rfSrc, stc, tagName)
 		if err != nil {
 			return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToStruct", b.Class), "map cannot cvt to struct", err)
 		}
@@ -2086,6 +2362,24 @@ func (b *BaseItfImpl) ToStruct(stc interface{}) (interface{}, error) {
 	return nil, itferr.NewConvFailed(fmt.Sprintf("%s#ToStruct", b.Class))
 }
 
+func (b *BaseItfImpl) ToTyped(ptr interface{}) error {
+	if b.ItfErr != nil {
+		return b.ItfErr
+	}
+
+	rfPtr := reflect.ValueOf(ptr)
+	if rfPtr.Kind() != reflect.Ptr || rfPtr.IsNil() {
+		return itferr.NewParamTypeErr(fmt.Sprintf("%s#ToTyped(%T)", b.Class, ptr))
+	}
+
+	rfV, err := pkg.ConvertToX(b.IterVal, rfPtr.Elem().Type(), conf.IsStrictNumberCvt(b.Ctx))
+	if err != nil {
+		return itferr.NewConvFailedX(fmt.Sprintf("%s#ToTyped(%T)", b.Class, ptr), "", err)
+	}
+	rfPtr.Elem().Set(rfV)
+	return nil
+}
+
 func (b *BaseItfImpl) Uniq() api.MapInterface {
 	if b.ItfErr != nil {
 		return b
@@ -2132,7 +2426,7 @@ func (b *BaseItfImpl) Index(index int) api.MapInterface {
 
 		v := reflect.ValueOf(b.IterVal)
 		switch v.Kind() {
-		case reflect.Map, reflect.Slice, reflect.Array, reflect.String, reflect.Interface:
+		case reflect.Map, reflect.Slice, reflect.Array, reflect.String, reflect.Interface, reflect.Struct:
 			return FrWithChain(b.Ctx, b.IterVal, b.IterChain)
 		}
 		return b
@@ -2158,7 +2452,7 @@ func (b *BaseItfImpl) Index(index int) api.MapInterface {
 
 		rfVV := pkg.ReflectToVal(b.IterVal)
 		switch rfVV.Kind() {
-		case reflect.Map, reflect.Slice, reflect.Array, reflect.String, reflect.Interface:
+		case reflect.Map, reflect.Slice, reflect.Array, reflect.String, reflect.Interface, reflect.Struct:
 			return FrWithChain(b.Ctx, b.IterVal, b.IterChain)
 		}
 		return b
@@ -2183,7 +2477,7 @@ func (b *BaseItfImpl) Index(index int) api.MapInterface {
 
 		rfVV := pkg.ReflectToVal(b.IterVal)
 		switch rfVV.Kind() {
-		case reflect.Map, reflect.Slice, reflect.Array, reflect.String, reflect.Interface:
+		case reflect.Map, reflect.Slice, reflect.Array, reflect.String, reflect.Interface, reflect.Struct:
 			return FrWithChain(b.Ctx, b.IterVal, b.IterChain)
 		}
 		return b
@@ -2237,7 +2531,13 @@ func (b *BaseItfImpl) ForEach(forFunc api.ForFunc) api.MapInterface {
 		return NewExceptItfImpl()
 	}
 
-	v := pkg.ReflectToVal(b.IterVal)
+	iterVal := b.IterVal
+	if pkg.IsStruct(iterVal) {
+		if structMap, err := pkg.StructToMap(iterVal); err == nil {
+			iterVal = structMap
+		}
+	}
+	v := pkg.ReflectToVal(iterVal)
 	switch v.Kind() {
 	case reflect.Slice, reflect.Array:
 		resultList := make([]interface{}, 0, v.Len())
@@ -2284,6 +2584,120 @@ func (b *BaseItfImpl) ForEach(forFunc api.ForFunc) api.MapInterface {
 	return NewExceptItfImpl()
 }
 
+// ForEachCtrl 同ForEach,forFunc可通过ctrl提前结束迭代或返回错误
+func (b *BaseItfImpl) ForEachCtrl(forFunc api.ForCtrlFunc) api.MapInterface {
+	if b.ItfErr != nil {
+		return b
+	}
+
+	items, _, itfErr := b.forItems("ForEachCtrl")
+	if itfErr != nil {
+		b.ItfErr = itfErr
+		return NewExceptItfImplErr(b.ItfErr)
+	}
+
+	resultList := make([]interface{}, 0, len(items))
+	resultMap := make(map[interface{}]interface{}, len(items))
+	for _, item := range items {
+		key, val, ctrl := forFunc(item.idx, item.key, item.val)
+		if ctrl != nil && ctrl != api.ForContinue && ctrl != api.ForBreak {
+			locate := fmt.Sprintf("%s#ForEachCtrl(idx:%d)", b.Class, item.idx)
+			if item.key != nil {
+				locate = fmt.Sprintf("%s#ForEachCtrl(key:%v)", b.Class, item.key)
+			}
+			b.ItfErr = itferr.NewIterCallbackErr(locate, ctrl)
+			return NewExceptItfImplErr(b.ItfErr)
+		}
+
+		if key != nil {
+			resultMap[key] = val
+		} else if val != nil {
+			resultList = append(resultList, val)
+		}
+		if ctrl == api.ForBreak {
+			break
+		}
+	}
+	return NewForeachItfImpl(b.Ctx, resultList, resultMap).WithIterChain(b.IterChain)
+}
+
+// forItem 待迭代的元素,list时key为nil
+type forItem struct {
+	idx int
+	key interface{}
+	val interface{}
+}
+
+// forItems 将当前的list/map(含json str,struct)拆解为待迭代的元素,顺序与ForEach一致,keyed表示元素是否为map的key-value
+func (b *BaseItfImpl) forItems(funcName string) (items []forItem, keyed bool, itfErr itferr.MapItfErr) {
+	if isJson, js := pkg.JsonChecker(b.IterVal); isJson {
+		if mapObj, err := pkg.JsonLoadsMap(js); err == nil {
+			b.IterVal = mapObj
+			b.IterChain.ReplaceBack(mapObj)
+		} else if listObj, err := pkg.JsonLoadsList(js); err == nil {
+			b.IterVal = listObj
+		} else {
+			return nil, false, itferr.NewConvFailedX(fmt.Sprintf("%s#%s", b.Class, funcName), "not json map or list str", err)
+		}
+	}
+
+	val := b.IterVal
+	if pkg.IsStruct(val) {
+		mapObj, err := pkg.StructToMap(val)
+		if err != nil {
+			return nil, false, itferr.NewConvFailedX(fmt.Sprintf("%s#%s", b.Class, funcName), "struct cannot cvt to map", err)
+		}
+		val = mapObj
+	}
+
+	v := pkg.ReflectToVal(val)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		items = make([]forItem, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			idxV := v.Index(i)
+			if !idxV.IsValid() || !idxV.CanInterface() {
+				continue
+			}
+			items = append(items, forItem{idx: i, val: idxV.Interface()})
+		}
+		return items, false, nil
+	case reflect.Map:
+		items = make([]forItem, 0, v.Len())
+		for i, rfK := range v.MapKeys() {
+			if !rfK.IsValid() || !rfK.CanInterface() {
+				continue
+			}
+			mpV := v.MapIndex(rfK)
+			if !mpV.IsValid() || !mpV.CanInterface() {
+				continue
+			}
+			items = append(items, forItem{idx: i, key: rfK.Interface(), val: mpV.Interface()})
+		}
+		return items, true, nil
+	}
+
+	return nil, false, itferr.NewFuncUsedErr(fmt.Sprintf("%s#%s", b.Class, funcName), "val is not map or list")
+}
+
+// forEachItems 按items的顺序执行forFunc并按ForEach的约定组装结果集
+func (b *BaseItfImpl) forEachItems(items []forItem, forFunc api.ForFunc) api.MapInterface {
+	resultList := make([]interface{}, 0, len(items))
+	resultMap := make(map[interface{}]interface{}, len(items))
+	for _, item := range items {
+		key, val := forFunc(item.idx, item.key, item.val)
+		if key == nil && val == nil {
+			continue
+		}
+		if key != nil {
+			resultMap[key] = val
+		} else {
+			resultList = append(resultList, val)
+		}
+	}
+	return NewForeachItfImpl(b.Ctx, resultList, resultMap).WithIterChain(b.IterChain)
+}
+
 // OriginTypeChecker ----------------------------------------------------------------------------------------
 func (b *BaseItfImpl) IsStr() (bool, error) {
 	if b.ItfErr != nil {
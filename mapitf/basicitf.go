@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/runingriver/mapinterface/api"
 	"github.com/runingriver/mapinterface/itferr"
+	"github.com/runingriver/mapinterface/pkg"
 	"reflect"
 )
 
@@ -40,15 +41,42 @@ func (m *BasicItfImpl) Index(index int) api.MapInterface {
 }
 
 func (m *BasicItfImpl) Get(key interface{}) api.MapInterface {
+	if m.ItfErr != nil {
+		return m
+	}
+	if pkg.IsStruct(m.IterVal) {
+		return m.structMapNode(fmt.Sprintf("BasicItfImpl#Get(%v)", key)).Get(key)
+	}
+
 	m.ItfErr = itferr.NewFuncUsedErr(fmt.Sprintf("BasicItfImpl#Get(%v)", key), "un-supported func")
 	return m
 }
 
 func (m *BasicItfImpl) GetAny(keys ...interface{}) api.MapInterface {
+	if m.ItfErr != nil {
+		return m
+	}
+	if pkg.IsStruct(m.IterVal) {
+		return m.structMapNode(fmt.Sprintf("BasicItfImpl#GetAny(%+v)", keys)).GetAny(keys...)
+	}
+
 	m.ItfErr = itferr.NewFuncUsedErr(fmt.Sprintf("BasicItfImpl#GetAny(%+v)", keys), "un-supported func")
 	return m
 }
 
+// structMapNode 将struct按json tag转换为map节点(见pkg.StructToMap),以便像map一样继续Get;
+// 转换结果是struct的副本,对其SetXxx不会修改原struct
+func (m *BasicItfImpl) structMapNode(locate string) api.MapInterface {
+	mapObj, err := pkg.StructToMap(m.IterVal)
+	if err != nil {
+		m.ItfErr = itferr.NewConvFailedX(locate, "struct cannot cvt to map", err)
+		return m
+	}
+	iterChain := m.IterChain.Clone()
+	iterChain.ReplaceBack(mapObj)
+	return NewMapStrItfImpl(m.Ctx, mapObj).WithIterChain(iterChain)
+}
+
 func (m *BasicItfImpl) WithIterChain(iterChain *IterChain) BasicItf {
 	if iterChain == nil {
 		return m
@@ -0,0 +1,84 @@
+package mapitf
+
+import (
+	"fmt"
+	"github.com/runingriver/mapinterface/api"
+	"github.com/runingriver/mapinterface/conf"
+	"github.com/runingriver/mapinterface/itferr"
+	"github.com/runingriver/mapinterface/logx"
+	"github.com/runingriver/mapinterface/pkg"
+	"sort"
+)
+
+func (b *BaseItfImpl) CountBy(path ...interface{}) api.MapInterface {
+	if b.ItfErr != nil {
+		return b
+	}
+	keys, counts, itfErr := b.countBy("CountBy", path...)
+	if itfErr != nil {
+		b.ItfErr = itfErr
+		return b
+	}
+
+	result := make(map[interface{}]interface{}, len(keys))
+	for _, key := range keys {
+		result[key] = counts[key]
+	}
+	return NewForeachItfImpl(b.Ctx, nil, result).WithIterChain(b.IterChain)
+}
+
+func (b *BaseItfImpl) MostCommon(n int, path ...interface{}) api.MapInterface {
+	if b.ItfErr != nil {
+		return b
+	}
+	keys, counts, itfErr := b.countBy("MostCommon", path...)
+	if itfErr != nil {
+		b.ItfErr = itfErr
+		return b
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return counts[keys[i]] > counts[keys[j]]
+	})
+	if n > 0 && n < len(keys) {
+		keys = keys[:n]
+	}
+	result := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, []interface{}{key, counts[key]})
+	}
+	return NewForeachListItfImpl(b.Ctx, result).WithIterChain(b.IterChain)
+}
+
+// countBy 返回按首次出现顺序排列的key及各key出现的次数
+func (b *BaseItfImpl) countBy(funcName string, path ...interface{}) ([]string, map[string]int, itferr.MapItfErr) {
+	listItf, itfErr := b.listOpVal(funcName)
+	if itfErr != nil {
+		return nil, nil, itfErr
+	}
+
+	keys, counts, skipCnt := make([]string, 0), make(map[string]int), 0
+	for i, item := range listItf {
+		if len(path) > 0 {
+			val, err := getByPath(b.Ctx, item, path)
+			if err != nil {
+				if conf.CONF.SkipCvtFailForToArrayType {
+					skipCnt++
+					continue
+				}
+				return nil, nil, itferr.NewConvFailedX(fmt.Sprintf("%s#%s(%d)", b.Class, funcName, i), "get val by path err", err)
+			}
+			item = val
+		}
+
+		key := pkg.ToStr(item)
+		if _, ok := counts[key]; !ok {
+			keys = append(keys, key)
+		}
+		counts[key]++
+	}
+	if skipCnt > 0 {
+		logx.CtxWarn(b.Ctx, "%s %d list val get by path failed", funcName, skipCnt)
+	}
+	return keys, counts, nil
+}
@@ -0,0 +1,236 @@
+package mapitf
+
+import (
+	"context"
+	"fmt"
+	"github.com/runingriver/mapinterface/api"
+	"github.com/runingriver/mapinterface/itferr"
+	"github.com/runingriver/mapinterface/pkg"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Flatten 将当前的map/list展开为一层的map,key为各层key(或索引)按分隔符拼接的路径
+// 注:空map,空list作为值保留,以保证Unflatten后可还原;key中包含分隔符时无法被Unflatten正确还原
+func (b *BaseItfImpl) Flatten(opt ...api.FlattenOpt) api.MapInterface {
+	if b.ItfErr != nil {
+		return b
+	}
+
+	flatOpt := newFlattenOpt(opt...)
+	val := b.IterVal
+	if isJson, js := pkg.JsonChecker(val); isJson {
+		if jsonVal, ok := loadsJsonContainer(js); ok {
+			val = jsonVal
+		}
+	}
+
+	rfV := pkg.ReflectToVal(val)
+	if rfV.Kind() != reflect.Map && rfV.Kind() != reflect.Slice && rfV.Kind() != reflect.Array {
+		b.ItfErr = itferr.NewConvFailedX(fmt.Sprintf("%s#Flatten", b.Class), "val is not map or list", nil)
+		return b
+	}
+
+	result := make(map[interface{}]interface{})
+	if rfV.Len() != 0 {
+		flattenTo(result, "", val, 0, flatOpt)
+	}
+	return NewForeachItfImpl(b.Ctx, nil, result).WithIterChain(b.IterChain)
+}
+
+// Unflatten Flatten的逆操作,将{"a.b.0":1,"a.b.1":2}还原为{"a":{"b":[1,2]}},m支持From所支持的各种map
+// 同一层的key均为从0开始连续的索引时还原为list,否则还原为map
+func Unflatten(m interface{}, opt ...api.FlattenOpt) api.MapInterface {
+	flatMap, err := toMap(context.TODO(), m)
+	if err != nil {
+		return NewExceptItfImplErr(itferr.NewConvFailedX("Unflatten", "param is not map", err))
+	}
+
+	flatOpt := newFlattenOpt(opt...)
+	root := &flatNode{}
+	for key, val := range flatMap {
+		node := root
+		for _, seg := range splitFlattenKey(key, flatOpt) {
+			if node.leaf {
+				return NewExceptItfImplErr(itferr.NewConvFailedX(fmt.Sprintf("Unflatten(%s)", key), "key conflict with other key", nil))
+			}
+			node = node.child(seg)
+		}
+		if node.leaf || len(node.children) != 0 {
+			return NewExceptItfImplErr(itferr.NewConvFailedX(fmt.Sprintf("Unflatten(%s)", key), "key conflict with other key", nil))
+		}
+		node.leaf, node.val = true, val
+	}
+
+	return From(root.build())
+}
+
+func newFlattenOpt(opt ...api.FlattenOpt) api.FlattenOpt {
+	flatOpt := api.FlattenOpt{}
+	if len(opt) > 0 {
+		flatOpt = opt[0]
+	}
+	if flatOpt.Sep == "" {
+		flatOpt.Sep = "."
+	}
+	return flatOpt
+}
+
+// loadsJsonContainer 仅当js是json map或json list时返回反序列化后的值
+func loadsJsonContainer(js string) (interface{}, bool) {
+	if mapVal, err := pkg.JsonLoadsMap(js); err == nil {
+		return mapVal, true
+	}
+	if listVal, err := pkg.JsonLoadsList(js); err == nil {
+		return listVal, true
+	}
+	return nil, false
+}
+
+func flattenTo(result map[interface{}]interface{}, prefix string, val interface{}, depth int, opt api.FlattenOpt) {
+	if opt.DescendJsonStr && depth > 0 {
+		if isJson, js := pkg.JsonChecker(val); isJson {
+			if jsonVal, ok := loadsJsonContainer(js); ok {
+				val = jsonVal
+			}
+		}
+	}
+
+	rfV := pkg.ReflectToVal(val)
+	canDescend := opt.MaxDepth <= 0 || depth < opt.MaxDepth
+	switch rfV.Kind() {
+	case reflect.Map:
+		if rfV.Len() == 0 || !canDescend {
+			break
+		}
+		for _, rfK := range rfV.MapKeys() {
+			mpV := rfV.MapIndex(rfK)
+			if !rfK.CanInterface() || !mpV.IsValid() || !mpV.CanInterface() {
+				continue
+			}
+			key := pkg.ToStr(rfK.Interface())
+			if prefix != "" {
+				key = prefix + opt.Sep + key
+			}
+			flattenTo(result, key, mpV.Interface(), depth+1, opt)
+		}
+		return
+	case reflect.Slice, reflect.Array:
+		if _, isByte := val.([]byte); isByte || rfV.Len() == 0 || !canDescend {
+			break
+		}
+		for i := 0; i < rfV.Len(); i++ {
+			idxV := rfV.Index(i)
+			if !idxV.IsValid() || !idxV.CanInterface() {
+				continue
+			}
+			key := strconv.Itoa(i)
+			if opt.IdxStyle == api.FlattenIdxBracket {
+				key = prefix + "[" + key + "]"
+			} else if prefix != "" {
+				key = prefix + opt.Sep + key
+			}
+			flattenTo(result, key, idxV.Interface(), depth+1, opt)
+		}
+		return
+	}
+
+	result[prefix] = val
+}
+
+// splitFlattenKey 按分隔符拆分key,IdxBracket时a[0][1]拆分为a,0,1
+func splitFlattenKey(key string, opt api.FlattenOpt) []string {
+	if opt.IdxStyle != api.FlattenIdxBracket {
+		return strings.Split(key, opt.Sep)
+	}
+
+	segs := make([]string, 0)
+	for _, part := range strings.Split(key, opt.Sep) {
+		pos := strings.Index(part, "[")
+		if pos < 0 || !strings.HasSuffix(part, "]") {
+			segs = append(segs, part)
+			continue
+		}
+		idxList := strings.Split(strings.TrimSuffix(part[pos+1:], "]"), "][")
+		for _, idx := range idxList {
+			if _, err := strconv.Atoi(idx); err != nil {
+				idxList = nil
+				break
+			}
+		}
+		if idxList == nil {
+			segs = append(segs, part)
+			continue
+		}
+		if name := part[:pos]; name != "" {
+			segs = append(segs, name)
+		}
+		segs = append(segs, idxList...)
+	}
+	return segs
+}
+
+type flatNode struct {
+	leaf     bool
+	val      interface{}
+	keys     []string
+	children map[string]*flatNode
+}
+
+func (n *flatNode) child(key string) *flatNode {
+	if n.children == nil {
+		n.children = make(map[string]*flatNode)
+	}
+	if c, ok := n.children[key]; ok {
+		return c
+	}
+	c := &flatNode{}
+	n.children[key] = c
+	n.keys = append(n.keys, key)
+	return c
+}
+
+func (n *flatNode) build() interface{} {
+	if n.leaf {
+		return n.val
+	}
+
+	if n.isList() {
+		result := make([]interface{}, len(n.keys))
+		for _, key := range n.keys {
+			idx, _ := strconv.Atoi(key)
+			result[idx] = n.children[key].build()
+		}
+		return result
+	}
+
+	result := make(map[string]interface{}, len(n.keys))
+	for _, key := range n.keys {
+		result[key] = n.children[key].build()
+	}
+	return result
+}
+
+// isList 子节点的key是否为从0开始的连续索引
+func (n *flatNode) isList() bool {
+	if len(n.keys) == 0 {
+		return false
+	}
+	idxList := make([]int, 0, len(n.keys))
+	for _, key := range n.keys {
+		idx, err := strconv.Atoi(key)
+		if err != nil || strconv.Itoa(idx) != key {
+			return false
+		}
+		idxList = append(idxList, idx)
+	}
+	sort.Ints(idxList)
+	for i, idx := range idxList {
+		if i != idx {
+			return false
+		}
+	}
+	return true
+}
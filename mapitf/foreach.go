@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"github.com/runingriver/mapinterface/api"
+	"github.com/runingriver/mapinterface/conf"
 	"github.com/runingriver/mapinterface/itferr"
+	"github.com/runingriver/mapinterface/logx"
 	"github.com/runingriver/mapinterface/pkg"
 )
 
@@ -56,6 +58,18 @@ func NewForeachItfImpl(ctx context.Context, listItf []interface{}, mapItf map[in
 	return fi
 }
 
+// NewForeachListItfImpl 结果集固定为list,为空时仍为ListDataType,ToList系列返回空list而非错误
+func NewForeachListItfImpl(ctx context.Context, listItf []interface{}) ForeachItf {
+	fi := NewForeachItfImpl(ctx, listItf, nil).(*ForeachItfImpl)
+	if fi.DataType == EmptyDataType {
+		fi.ListItf = make([]interface{}, 0)
+		fi.BaseItfImpl.IterVal = fi.ListItf
+		fi.BaseItfImpl.IterChain = NewLinkedList(fi.ListItf)
+		fi.DataType = ListDataType
+	}
+	return fi
+}
+
 func (m *ForeachItfImpl) WithIterChain(iterChain *IterChain) ForeachItf {
 	if iterChain == nil {
 		return m
@@ -104,82 +118,35 @@ func (m *ForeachItfImpl) ToMap() (map[string]interface{}, error) {
 }
 
 func (m *ForeachItfImpl) ToMapInt() (map[int]interface{}, error) {
-	cvtResult := make(map[int]interface{}, len(m.MapItf))
-	for k, v := range m.MapItf {
-		if iv, ok := pkg.ToInt64(k); ok == nil {
-			cvtResult[int(iv)] = v
-		}
-	}
-	return cvtResult, nil
+	return foreachToMap(m, "ToMapInt", m.toInt, m.itfVal)
 }
 
 func (m *ForeachItfImpl) ToMapInt64() (map[int64]interface{}, error) {
-	cvtResult := make(map[int64]interface{}, len(m.MapItf))
-	for k, v := range m.MapItf {
-		if iv, ok := pkg.ToInt64(k); ok == nil {
-			cvtResult[iv] = v
-		}
-	}
-	return cvtResult, nil
+	return foreachToMap(m, "ToMapInt64", m.toInt64, m.itfVal)
 }
 
 func (m *ForeachItfImpl) ToMapInt32() (map[int32]interface{}, error) {
-	cvtResult := make(map[int32]interface{}, len(m.MapItf))
-	for k, v := range m.MapItf {
-		if iv, ok := pkg.ToInt64(k); ok == nil {
-			cvtResult[int32(iv)] = v
-		}
-	}
-	return cvtResult, nil
+	return foreachToMap(m, "ToMapInt32", m.toInt32, m.itfVal)
 }
 
 func (m *ForeachItfImpl) ToMapUint() (map[uint]interface{}, error) {
-	cvtResult := make(map[uint]interface{}, len(m.MapItf))
-	for k, v := range m.MapItf {
-		if iv, ok := pkg.ToInt64(k); ok == nil {
-			cvtResult[uint(iv)] = v
-		}
-	}
-	return cvtResult, nil
+	return foreachToMap(m, "ToMapUint", m.toUint, m.itfVal)
 }
 
 func (m *ForeachItfImpl) ToMapUint64() (map[uint64]interface{}, error) {
-	cvtResult := make(map[uint64]interface{}, len(m.MapItf))
-	for k, v := range m.MapItf {
-		if iv, ok := pkg.ToInt64(k); ok == nil {
-			cvtResult[uint64(iv)] = v
-		}
-	}
-	return cvtResult, nil
+	return foreachToMap(m, "ToMapUint64", m.toUint64, m.itfVal)
 }
+
 func (m *ForeachItfImpl) ToMapUint32() (map[uint32]interface{}, error) {
-	cvtResult := make(map[uint32]interface{}, len(m.MapItf))
-	for k, v := range m.MapItf {
-		if iv, ok := pkg.ToInt64(k); ok == nil {
-			cvtResult[uint32(iv)] = v
-		}
-	}
-	return cvtResult, nil
+	return foreachToMap(m, "ToMapUint32", m.toUint32, m.itfVal)
 }
 
 func (m *ForeachItfImpl) ToMapFloat32() (map[float32]interface{}, error) {
-	cvtResult := make(map[float32]interface{}, len(m.MapItf))
-	for k, v := range m.MapItf {
-		if iv, ok := pkg.ToFloat32(k); ok == nil {
-			cvtResult[iv] = v
-		}
-	}
-	return cvtResult, nil
+	return foreachToMap(m, "ToMapFloat32", m.toFloat32, m.itfVal)
 }
 
 func (m *ForeachItfImpl) ToMapFloat64() (map[float64]interface{}, error) {
-	cvtResult := make(map[float64]interface{}, len(m.MapItf))
-	for k, v := range m.MapItf {
-		if iv, ok := pkg.ToFloat64(k); ok == nil {
-			cvtResult[iv] = v
-		}
-	}
-	return cvtResult, nil
+	return foreachToMap(m, "ToMapFloat64", m.toFloat64, m.itfVal)
 }
 
 func (m *ForeachItfImpl) ToMapItf() (map[interface{}]interface{}, error) {
@@ -195,51 +162,82 @@ func (m *ForeachItfImpl) ToMapStrToStr() (map[string]string, error) {
 }
 
 func (m *ForeachItfImpl) ToMapIntToInt() (map[int]int, error) {
-	cvtResult := make(map[int]int, len(m.MapItf))
-	for k, v := range m.MapItf {
-		ik, ok1 := pkg.ToInt64(k)
-		iv, ok2 := pkg.ToInt64(v)
-		if ok1 == nil && ok2 == nil {
-			cvtResult[int(ik)] = int(iv)
-		}
-	}
-	return cvtResult, nil
+	return foreachToMap(m, "ToMapIntToInt", m.toInt, m.toInt)
 }
 
 func (m *ForeachItfImpl) ToMapInt64ToInt64() (map[int64]int64, error) {
-	cvtResult := make(map[int64]int64, len(m.MapItf))
-	for k, v := range m.MapItf {
-		ik, ok1 := pkg.ToInt64(k)
-		iv, ok2 := pkg.ToInt64(v)
-		if ok1 == nil && ok2 == nil {
-			cvtResult[ik] = iv
-		}
-	}
-	return cvtResult, nil
+	return foreachToMap(m, "ToMapInt64ToInt64", m.toInt64, m.toInt64)
 }
 
 func (m *ForeachItfImpl) ToMapFloat64ToFloat64() (map[float64]float64, error) {
-	cvtResult := make(map[float64]float64, len(m.MapItf))
-	for k, v := range m.MapItf {
-		ik, ok1 := pkg.ToFloat64(k)
-		iv, ok2 := pkg.ToFloat64(v)
-		if ok1 == nil && ok2 == nil {
-			cvtResult[ik] = iv
-		}
-	}
-	return cvtResult, nil
+	return foreachToMap(m, "ToMapFloat64ToFloat64", m.toFloat64, m.toFloat64)
 }
 
 func (m *ForeachItfImpl) ToMapFloat32ToFloat32() (map[float32]float32, error) {
-	cvtResult := make(map[float32]float32, len(m.MapItf))
+	return foreachToMap(m, "ToMapFloat32ToFloat32", m.toFloat32, m.toFloat32)
+}
+
+// foreachToMap 按cvtKey,cvtVal转换MapItf的key,value,数值转换同BaseItfImpl(遵循严格模式),失败时参考SkipCvtFailForToMapType
+func foreachToMap[K comparable, V any](m *ForeachItfImpl, fn string, cvtKey func(interface{}) (K, error), cvtVal func(interface{}) (V, error)) (map[K]V, error) {
+	if m.ItfErr != nil {
+		return nil, m.ItfErr
+	}
+
+	result := make(map[K]V, len(m.MapItf))
 	for k, v := range m.MapItf {
-		ik, ok1 := pkg.ToFloat32(k)
-		iv, ok2 := pkg.ToFloat32(v)
-		if ok1 == nil && ok2 == nil {
-			cvtResult[ik] = iv
+		ck, err := cvtKey(k)
+		var cv V
+		if err == nil {
+			cv, err = cvtVal(v)
 		}
+		if err != nil {
+			if conf.CONF.SkipCvtFailForToMapType {
+				continue
+			}
+			return nil, itferr.NewConvFailedX(fmt.Sprintf("ForeachItfImpl#%s(%v)", fn, k), "map key value convert failed", err)
+		}
+		result[ck] = cv
 	}
-	return cvtResult, nil
+	if len(result) != len(m.MapItf) {
+		logx.CtxWarn(m.Ctx, "%s %d key value convert failed", fn, len(m.MapItf)-len(result))
+	}
+	return result, nil
+}
+
+func (m *ForeachItfImpl) itfVal(v interface{}) (interface{}, error) {
+	return v, nil
+}
+
+func (m *ForeachItfImpl) toInt(v interface{}) (int, error) {
+	i, err := m.toIntN(v, 0)
+	return int(i), err
+}
+
+func (m *ForeachItfImpl) toInt64(v interface{}) (int64, error) {
+	return m.toIntN(v, 64)
+}
+
+func (m *ForeachItfImpl) toInt32(v interface{}) (int32, error) {
+	i, err := m.toIntN(v, 32)
+	return int32(i), err
+}
+
+func (m *ForeachItfImpl) toUint(v interface{}) (uint, error) {
+	u, err := m.toUintN(v, 0)
+	return uint(u), err
+}
+
+func (m *ForeachItfImpl) toUint64(v interface{}) (uint64, error) {
+	return m.toUintN(v, 64)
+}
+
+func (m *ForeachItfImpl) toUint32(v interface{}) (uint32, error) {
+	u, err := m.toUintN(v, 32)
+	return uint32(u), err
+}
+
+func (m *ForeachItfImpl) toFloat64(v interface{}) (float64, error) {
+	return m.toFloatN(v, 64)
 }
 
 func (m *ForeachItfImpl) ToList() ([]interface{}, error) {
@@ -287,8 +285,8 @@ func (m *ForeachItfImpl) ToListBool() ([]bool, error) {
 	return m.BaseItfImpl.ToListBool()
 }
 
-func (m *ForeachItfImpl) ToStruct(out interface{}) (interface{}, error) {
-	return m.BaseItfImpl.ToStruct(out)
+func (m *ForeachItfImpl) ToStruct(out interface{}, opt ...api.StructOpt) (interface{}, error) {
+	return m.BaseItfImpl.ToStruct(out, opt...)
 }
 
 func (m *ForeachItfImpl) IsStr() (bool, error) {
@@ -322,6 +320,26 @@ func (m *ForeachItfImpl) ForEach(forFunc api.ForFunc) api.MapInterface {
 	return m
 }
 
+func (m *ForeachItfImpl) ForEachCtrl(forFunc api.ForCtrlFunc) api.MapInterface {
+	m.ItfErr = itferr.NewMapItfErr("ForeachItfImpl#ForEachCtrl", itferr.UnSupportInterfaceFunc, "un-support ForEach to ForEach", nil)
+	return m
+}
+
+func (m *ForeachItfImpl) ForEachOrdered(order api.IterOrder, forFunc api.ForFunc) api.MapInterface {
+	m.ItfErr = itferr.NewMapItfErr("ForeachItfImpl#ForEachOrdered", itferr.UnSupportInterfaceFunc, "un-support ForEach to ForEach", nil)
+	return m
+}
+
+func (m *ForeachItfImpl) ForEachSorted(less api.KeyLessFunc, forFunc api.ForFunc) api.MapInterface {
+	m.ItfErr = itferr.NewMapItfErr("ForeachItfImpl#ForEachSorted", itferr.UnSupportInterfaceFunc, "un-support ForEach to ForEach", nil)
+	return m
+}
+
+func (m *ForeachItfImpl) ParallelForEach(workers int, forFunc api.ForFunc) api.MapInterface {
+	m.ItfErr = itferr.NewMapItfErr("ForeachItfImpl#ParallelForEach", itferr.UnSupportInterfaceFunc, "un-support ForEach to ForEach", nil)
+	return m
+}
+
 func (m *ForeachItfImpl) SetMap(key interface{}, val interface{}) (orgVal interface{}, err error) {
 	m.ItfErr = itferr.NewMapItfErr("ForeachItfImpl#SetMap", itferr.UnSupportInterfaceFunc, "un-support do ForEach then Set", nil)
 	return nil, m.ItfErr
@@ -0,0 +1,78 @@
+package mapitf
+
+import (
+	"context"
+	"fmt"
+	"github.com/runingriver/mapinterface/api"
+	"github.com/runingriver/mapinterface/conf"
+	"github.com/runingriver/mapinterface/itferr"
+	"github.com/runingriver/mapinterface/logx"
+	"github.com/runingriver/mapinterface/pkg"
+	"reflect"
+)
+
+// As 将node的值转换为T,T可以是基础类型,由支持的类型组成的slice,map(可嵌套),struct以及它们的指针,转换规则见pkg.ConvertTo
+// 如: mapitf.As[map[string][]int64](mapitf.From(jsonStr).Get("scores"))
+func As[T any](node api.MapInterface) (T, error) {
+	var result T
+	val, err := node.Val()
+	if err != nil {
+		return result, err
+	}
+
+	rfV, cvtErr := pkg.ConvertToX(val, reflect.TypeOf(&result).Elem(), conf.IsStrictNumberCvt(nodeCtx(node)))
+	if cvtErr != nil {
+		return result, itferr.NewConvFailedX(fmt.Sprintf("As[%T]", result), "", cvtErr)
+	}
+	reflect.ValueOf(&result).Elem().Set(rfV)
+	return result, nil
+}
+
+// GetAs 等价于As[T](From(v).GetAny(path...)),path为空时转换v本身
+func GetAs[T any](v interface{}, path ...interface{}) (T, error) {
+	node := From(v)
+	if len(path) > 0 {
+		node = node.GetAny(path...)
+	}
+	return As[T](node)
+}
+
+// Collect 将list(含json list str)的每个元素转换为T;map时收集所有的value,顺序同ForEach
+// 元素转换失败时按SkipCvtFailForToArrayType决定跳过或返回错误
+func Collect[T any](node api.MapInterface) ([]T, error) {
+	var elem T
+	elemType := reflect.TypeOf(&elem).Elem()
+
+	ctx := nodeCtx(node)
+	strict := conf.IsStrictNumberCvt(ctx)
+	result := make([]T, 0)
+	skipCnt := 0
+	it := node.Iter()
+	for it.Next() {
+		rfV, err := pkg.ConvertToX(it.RawVal(), elemType, strict)
+		if err != nil {
+			if conf.CONF.SkipCvtFailForToArrayType {
+				skipCnt++
+				continue
+			}
+			return nil, itferr.NewConvFailedX(fmt.Sprintf("Collect[%T](%d)", elem, it.Index()), "", err)
+		}
+		reflect.ValueOf(&elem).Elem().Set(rfV)
+		result = append(result, elem)
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	if skipCnt > 0 {
+		logx.CtxWarn(ctx, "Collect %d list val convert failed", skipCnt)
+	}
+	return result, nil
+}
+
+// nodeCtx node的ctx,非本包实现的MapInterface返回context.TODO()
+func nodeCtx(node api.MapInterface) context.Context {
+	if n, ok := node.(interface{ context() context.Context }); ok && n.context() != nil {
+		return n.context()
+	}
+	return context.TODO()
+}
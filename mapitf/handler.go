@@ -41,10 +41,16 @@ func FrWithChain(ctx context.Context, itf interface{}, iterChain *IterChain) api
 
 func doStrFromStr(ctx context.Context, vv string, iterChain *IterChain) api.MapInterface {
 	if mapStrItf, err := pkg.JsonLoadsMap(vv); err == nil {
+		if iterChain == nil {
+			iterChain = NewLinkedList(vv) // 由WithIterChain替换为map,同时记录json原文
+		}
 		return NewMapStrItfImpl(ctx, mapStrItf).WithIterChain(iterChain)
 	}
 
 	if listItf, err := pkg.JsonLoadsList(vv); err == nil {
+		if iterChain == nil {
+			iterChain = NewLinkedList(vv)
+		}
 		return NewMapListItfImpl(ctx, listItf).WithIterChain(iterChain)
 	}
 	return NewBasicItfImpl(ctx, vv).WithIterChain(iterChain)
@@ -0,0 +1,133 @@
+package mapitf
+
+import (
+	"fmt"
+	"github.com/runingriver/mapinterface/api"
+	"github.com/runingriver/mapinterface/itferr"
+	"github.com/runingriver/mapinterface/pkg"
+	"reflect"
+)
+
+func (b *BaseItfImpl) Iter() api.Iterator {
+	it := &iterCursor{b: b, idx: -1}
+	if b.ItfErr != nil {
+		it.err = b.ItfErr
+		return it
+	}
+
+	if isJson, js := pkg.JsonChecker(b.IterVal); isJson {
+		if mapObj, err := pkg.JsonLoadsMap(js); err == nil {
+			b.IterVal = mapObj
+			b.IterChain.ReplaceBack(mapObj)
+		} else if listObj, err := pkg.JsonLoadsList(js); err == nil {
+			b.IterVal = listObj
+			b.IterChain.ReplaceBack(listObj)
+		} else {
+			it.err = itferr.NewConvFailedX(fmt.Sprintf("%s#Iter", b.Class), "not json map or list str", err)
+			return it
+		}
+	}
+
+	val := b.IterVal
+	if pkg.IsStruct(val) {
+		mapObj, err := pkg.StructToMap(val)
+		if err != nil {
+			it.err = itferr.NewConvFailedX(fmt.Sprintf("%s#Iter", b.Class), "struct cannot cvt to map", err)
+			return it
+		}
+		val = mapObj
+	}
+
+	rfV := pkg.ReflectToVal(val)
+	switch rfV.Kind() {
+	case reflect.Slice, reflect.Array:
+		it.listV = rfV
+	case reflect.Map:
+		it.mapIter = rfV.MapRange()
+	default:
+		it.err = itferr.NewFuncUsedErr(fmt.Sprintf("%s#Iter", b.Class), "val is not map or list")
+	}
+	return it
+}
+
+// iterCursor Iterator的实现,list按索引取值,map基于reflect.MapIter,均不复制元素
+type iterCursor struct {
+	b *BaseItfImpl
+
+	listV   reflect.Value
+	listPos int
+	mapIter *reflect.MapIter
+
+	idx      int
+	key, val interface{}
+	err      itferr.MapItfErr
+}
+
+func (it *iterCursor) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	if it.mapIter != nil {
+		for it.mapIter.Next() {
+			rfK, mpV := it.mapIter.Key(), it.mapIter.Value()
+			if !rfK.CanInterface() || !mpV.IsValid() || !mpV.CanInterface() {
+				continue
+			}
+			it.idx++
+			it.key, it.val = rfK.Interface(), mpV.Interface()
+			return true
+		}
+		it.mapIter = nil
+		return false
+	}
+
+	for it.listV.IsValid() && it.listPos < it.listV.Len() {
+		idxV := it.listV.Index(it.listPos)
+		it.listPos++
+		if !idxV.IsValid() || !idxV.CanInterface() {
+			continue
+		}
+		it.idx = it.listPos - 1
+		it.val = idxV.Interface()
+		return true
+	}
+	it.listV = reflect.Value{}
+	return false
+}
+
+func (it *iterCursor) Index() int {
+	return it.idx
+}
+
+func (it *iterCursor) Key() interface{} {
+	return it.key
+}
+
+func (it *iterCursor) Value() api.MapInterface {
+	if it.err != nil {
+		return NewExceptItfImplErr(it.err)
+	}
+	if it.idx < 0 {
+		return NewExceptItfImplErr(itferr.NewFuncUsedErr(fmt.Sprintf("%s#Iter#Value", it.b.Class), "call Next first"))
+	}
+
+	iterChain := it.b.IterChain.Clone()
+	if it.key != nil {
+		iterChain.PushBackByKey(it.key, it.val)
+	} else {
+		iterChain.PushBackByIdx(it.idx, it.val)
+	}
+	return FrWithChain(it.b.Ctx, it.val, iterChain)
+}
+
+func (it *iterCursor) RawVal() interface{} {
+	return it.val
+}
+
+func (it *iterCursor) Err() error {
+	if it.err == nil {
+		return nil
+	}
+	return it.err
+}
@@ -19,6 +19,7 @@ type IterCtx struct {
 	Key interface{} // 取到当前值的Key
 	Val interface{} // 当前的值
 
+	Raw string // 当前值由json字符串反序列化而来时,记录原始的json字符串
 }
 
 func NewEnterIterCtx(val interface{}) *IterCtx {
@@ -57,7 +58,12 @@ func (i *IterChain) ReplaceBack(val interface{}) {
 	if e := i.List.Back(); e != nil {
 		i.List.Remove(e)
 		ic := e.Value.(*IterCtx)
-		i.List.PushBack(NewIterCtx(ic.Key, val, ic.Idx))
+		newIc := NewIterCtx(ic.Key, val, ic.Idx)
+		newIc.Raw = ic.Raw
+		if raw, ok := ic.Val.(string); ok {
+			newIc.Raw = raw
+		}
+		i.List.PushBack(newIc)
 		return
 	}
 
@@ -96,6 +102,31 @@ func (i *IterChain) Clone() *IterChain {
 	return ic
 }
 
+// JsonKeyOrder 当前值(或其某一层父节点)来源于json字符串时,返回当前map在json原文中key的顺序
+func (i *IterChain) JsonKeyOrder() ([]string, error) {
+	path := make([]interface{}, 0)
+	for e := i.List.Back(); e != nil; e = e.Prev() {
+		ic := e.Value.(*IterCtx)
+		raw := ic.Raw
+		if js, ok := ic.Val.(string); ok {
+			raw = js
+		}
+		if raw != "" {
+			for l, r := 0, len(path)-1; l < r; l, r = l+1, r-1 {
+				path[l], path[r] = path[r], path[l]
+			}
+			return pkg.JsonKeyOrder(raw, path...)
+		}
+
+		if ic.Key != nil {
+			path = append(path, ic.Key)
+		} else {
+			path = append(path, ic.Idx)
+		}
+	}
+	return nil, itferr.NewMapItfErrX("IterChain#JsonKeyOrder", itferr.ValueTypeErr)
+}
+
 func (i *IterChain) HeadVal() interface{} {
 	if e := i.List.Front(); e != nil {
 		return e.Value.(*IterCtx).Val
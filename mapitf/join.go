@@ -0,0 +1,112 @@
+package mapitf
+
+import (
+	"context"
+	"fmt"
+	"github.com/runingriver/mapinterface/api"
+	"github.com/runingriver/mapinterface/conf"
+	"github.com/runingriver/mapinterface/itferr"
+	"github.com/runingriver/mapinterface/logx"
+	"github.com/runingriver/mapinterface/pkg"
+)
+
+// Join 先对other按rightPath建立hash索引,再遍历当前list逐条关联;key为nil或取不到的记录视为未匹配
+func (b *BaseItfImpl) Join(other interface{}, leftPath, rightPath interface{}, kind api.JoinKind, opt ...api.JoinOpt) api.MapInterface {
+	if b.ItfErr != nil {
+		return b
+	}
+	joinOpt := api.JoinOpt{}
+	if len(opt) > 0 {
+		joinOpt = opt[0]
+	}
+
+	leftList, itfErr := b.listOpVal("Join")
+	if itfErr != nil {
+		b.ItfErr = itfErr
+		return b
+	}
+	rightList, err := Fr(b.Ctx, other).ToList()
+	if err != nil {
+		b.ItfErr = itferr.NewConvFailedX(fmt.Sprintf("%s#Join", b.Class), "other val is not list", err)
+		return b
+	}
+
+	rightIndex := make(map[string][]map[string]interface{}, len(rightList))
+	for i, item := range rightList {
+		record, err := toMap(b.Ctx, item)
+		if err != nil {
+			if conf.CONF.SkipCvtFailForToArrayType {
+				logx.CtxWarn(b.Ctx, "Join right record(%d) is not map:%v", i, err)
+				continue
+			}
+			b.ItfErr = itferr.NewConvFailedX(fmt.Sprintf("%s#Join(right:%d)", b.Class, i), "record is not map", err)
+			return b
+		}
+		if key, ok := joinKey(b.Ctx, record, rightPath); ok {
+			rightIndex[key] = append(rightIndex[key], record)
+		}
+	}
+
+	result := make([]interface{}, 0, len(leftList))
+	for i, item := range leftList {
+		record, err := toMap(b.Ctx, item)
+		if err != nil {
+			if conf.CONF.SkipCvtFailForToArrayType {
+				logx.CtxWarn(b.Ctx, "Join left record(%d) is not map:%v", i, err)
+				continue
+			}
+			b.ItfErr = itferr.NewConvFailedX(fmt.Sprintf("%s#Join(left:%d)", b.Class, i), "record is not map", err)
+			return b
+		}
+
+		var matches []map[string]interface{}
+		if key, ok := joinKey(b.Ctx, record, leftPath); ok {
+			matches = rightIndex[key]
+		}
+		if len(matches) == 0 {
+			if kind == api.LeftJoin {
+				result = append(result, joinRecord(record, nil, joinOpt))
+			}
+			continue
+		}
+		for _, match := range matches {
+			result = append(result, joinRecord(record, match, joinOpt))
+		}
+	}
+	return NewForeachListItfImpl(b.Ctx, result).WithIterChain(b.IterChain)
+}
+
+// joinKey 取record在path处的值并按ToStr归一化
+func joinKey(ctx context.Context, record map[string]interface{}, path interface{}) (string, bool) {
+	keys, ok := path.([]interface{})
+	if !ok {
+		keys = []interface{}{path}
+	}
+	val, err := getByPath(ctx, record, keys)
+	if err != nil || pkg.Interpret(val) == nil {
+		return "", false
+	}
+	return pkg.ToStr(val), true
+}
+
+// joinRecord 复制left并合并right,right为nil表示未匹配
+func joinRecord(left, right map[string]interface{}, opt api.JoinOpt) map[string]interface{} {
+	result := make(map[string]interface{}, len(left)+len(right))
+	for k, v := range left {
+		result[k] = v
+	}
+	if opt.NestKey != "" {
+		if right == nil {
+			result[opt.NestKey] = nil
+		} else {
+			result[opt.NestKey] = right
+		}
+		return result
+	}
+	for k, v := range right {
+		if _, ok := result[k]; !ok {
+			result[k] = v
+		}
+	}
+	return result
+}
@@ -64,7 +64,7 @@ func (m *MapListItfImpl) Index(index int) api.MapInterface {
 
 	rfVV := pkg.ReflectToVal(m.IterVal)
 	switch rfVV.Kind() {
-	case reflect.Map, reflect.Slice, reflect.Array, reflect.String, reflect.Interface:
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.String, reflect.Interface, reflect.Struct:
 		return FrWithChain(m.Ctx, m.IterVal, m.IterChain)
 	}
 
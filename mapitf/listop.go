@@ -0,0 +1,109 @@
+package mapitf
+
+import (
+	"fmt"
+	"github.com/runingriver/mapinterface/api"
+	"github.com/runingriver/mapinterface/itferr"
+)
+
+func (b *BaseItfImpl) Chunk(n int) api.MapInterface {
+	if b.ItfErr != nil {
+		return b
+	}
+	if n <= 0 {
+		b.ItfErr = itferr.NewFuncUsedErr(fmt.Sprintf("%s#Chunk(%d)", b.Class, n), "n must be greater than 0")
+		return b
+	}
+	listItf, itfErr := b.listOpVal("Chunk")
+	if itfErr != nil {
+		b.ItfErr = itfErr
+		return b
+	}
+
+	chunks := make([]interface{}, 0, (len(listItf)+n-1)/n)
+	for start := 0; start < len(listItf); start += n {
+		end := start + n
+		if end > len(listItf) {
+			end = len(listItf)
+		}
+		chunks = append(chunks, listItf[start:end:end])
+	}
+	return NewForeachListItfImpl(b.Ctx, chunks).WithIterChain(b.IterChain)
+}
+
+func (b *BaseItfImpl) Window(size, step int) api.MapInterface {
+	if b.ItfErr != nil {
+		return b
+	}
+	if size <= 0 || step <= 0 {
+		b.ItfErr = itferr.NewFuncUsedErr(fmt.Sprintf("%s#Window(%d,%d)", b.Class, size, step), "size and step must be greater than 0")
+		return b
+	}
+	listItf, itfErr := b.listOpVal("Window")
+	if itfErr != nil {
+		b.ItfErr = itfErr
+		return b
+	}
+
+	windows := make([]interface{}, 0)
+	for start := 0; start+size <= len(listItf); start += step {
+		windows = append(windows, listItf[start:start+size:start+size])
+	}
+	return NewForeachListItfImpl(b.Ctx, windows).WithIterChain(b.IterChain)
+}
+
+func (b *BaseItfImpl) Partition(pred api.PredFunc) api.MapInterface {
+	if b.ItfErr != nil {
+		return b
+	}
+	listItf, itfErr := b.listOpVal("Partition")
+	if itfErr != nil {
+		b.ItfErr = itfErr
+		return b
+	}
+
+	matches, others := make([]interface{}, 0), make([]interface{}, 0)
+	for i, item := range listItf {
+		if pred(i, item) {
+			matches = append(matches, item)
+		} else {
+			others = append(others, item)
+		}
+	}
+	return NewForeachListItfImpl(b.Ctx, []interface{}{matches, others}).WithIterChain(b.IterChain)
+}
+
+func (b *BaseItfImpl) Zip(otherPath ...interface{}) api.MapInterface {
+	if b.ItfErr != nil {
+		return b
+	}
+	listItf, itfErr := b.listOpVal("Zip")
+	if itfErr != nil {
+		b.ItfErr = itfErr
+		return b
+	}
+	otherList, err := Fr(b.Ctx, b.IterChain.HeadVal()).GetAny(otherPath...).ToList()
+	if err != nil {
+		b.ItfErr = itferr.NewConvFailedX(fmt.Sprintf("%s#Zip(%v)", b.Class, otherPath), "other val is not list", err)
+		return b
+	}
+
+	size := len(listItf)
+	if len(otherList) < size {
+		size = len(otherList)
+	}
+	pairs := make([]interface{}, 0, size)
+	for i := 0; i < size; i++ {
+		pairs = append(pairs, []interface{}{listItf[i], otherList[i]})
+	}
+	return NewForeachListItfImpl(b.Ctx, pairs).WithIterChain(b.IterChain)
+}
+
+// listOpVal 以[]interface{}的形式返回当前list(含json list str)
+func (b *BaseItfImpl) listOpVal(funcName string) ([]interface{}, itferr.MapItfErr) {
+	listItf, err := b.ToList()
+	if err != nil {
+		return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#%s", b.Class, funcName), "val is not list", err)
+	}
+	return listItf, nil
+}
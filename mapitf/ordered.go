@@ -0,0 +1,80 @@
+package mapitf
+
+import (
+	"github.com/runingriver/mapinterface/api"
+	"github.com/runingriver/mapinterface/pkg"
+	"sort"
+)
+
+// ForEachOrdered 按order指定的顺序迭代Map,迭代前先确定key的顺序,i为key在该顺序下的位置
+func (b *BaseItfImpl) ForEachOrdered(order api.IterOrder, forFunc api.ForFunc) api.MapInterface {
+	if b.ItfErr != nil {
+		return b
+	}
+
+	items, keyed, itfErr := b.forItems("ForEachOrdered")
+	if itfErr != nil {
+		b.ItfErr = itfErr
+		return NewExceptItfImplErr(b.ItfErr)
+	}
+	if !keyed {
+		return b.forEachItems(items, forFunc)
+	}
+
+	switch order {
+	case api.IterOrderSortKey:
+		sortForItems(items, pkg.KeyLess)
+	case api.IterOrderJsonKey:
+		jsonKeys, err := b.IterChain.JsonKeyOrder()
+		if err != nil {
+			sortForItems(items, pkg.KeyLess)
+			break
+		}
+		keyPos := make(map[string]int, len(jsonKeys))
+		for i, key := range jsonKeys {
+			if _, ok := keyPos[key]; !ok {
+				keyPos[key] = i
+			}
+		}
+		// 不在json原文中的key(如通过SetMap新增的)按key升序排在最后
+		sortForItems(items, func(k1, k2 interface{}) bool {
+			pos1, ok1 := keyPos[pkg.ToStr(k1)]
+			pos2, ok2 := keyPos[pkg.ToStr(k2)]
+			if ok1 && ok2 {
+				return pos1 < pos2
+			}
+			if ok1 != ok2 {
+				return ok1
+			}
+			return pkg.KeyLess(k1, k2)
+		})
+	}
+	return b.forEachItems(items, forFunc)
+}
+
+// ForEachSorted 按less自定义的顺序迭代Map
+func (b *BaseItfImpl) ForEachSorted(less api.KeyLessFunc, forFunc api.ForFunc) api.MapInterface {
+	if b.ItfErr != nil {
+		return b
+	}
+
+	items, keyed, itfErr := b.forItems("ForEachSorted")
+	if itfErr != nil {
+		b.ItfErr = itfErr
+		return NewExceptItfImplErr(b.ItfErr)
+	}
+	if keyed {
+		sortForItems(items, less)
+	}
+	return b.forEachItems(items, forFunc)
+}
+
+// sortForItems 按key排序,并将idx重置为排序后的位置
+func sortForItems(items []forItem, less api.KeyLessFunc) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return less(items[i].key, items[j].key)
+	})
+	for i := range items {
+		items[i].idx = i
+	}
+}
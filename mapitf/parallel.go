@@ -0,0 +1,109 @@
+package mapitf
+
+import (
+	"context"
+	"fmt"
+	"github.com/runingriver/mapinterface/api"
+	"github.com/runingriver/mapinterface/itferr"
+	"runtime"
+	"sync"
+)
+
+// ParallelForEach 使用workers个goroutine并发执行forFunc,forFunc需自行保证并发安全
+// 结果按元素的迭代顺序组装,因此结果集与ForEach一致;ctx被cancel或forFunc panic时停止派发剩余元素并返回错误
+func (b *BaseItfImpl) ParallelForEach(workers int, forFunc api.ForFunc) api.MapInterface {
+	if b.ItfErr != nil {
+		return b
+	}
+
+	items, _, itfErr := b.forItems("ParallelForEach")
+	if itfErr != nil {
+		b.ItfErr = itfErr
+		return NewExceptItfImplErr(b.ItfErr)
+	}
+
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > len(items) {
+		workers = len(items)
+	}
+
+	parentCtx := b.Ctx
+	if parentCtx == nil {
+		parentCtx = context.TODO()
+	}
+	ctx, cancel := context.WithCancel(parentCtx)
+	defer cancel()
+
+	type forResult struct {
+		key interface{}
+		val interface{}
+	}
+	results := make([]forResult, len(items))
+	jobs := make(chan int)
+
+	var (
+		wg      sync.WaitGroup
+		errOnce sync.Once
+		iterErr itferr.MapItfErr
+	)
+	setErr := func(err itferr.MapItfErr) {
+		errOnce.Do(func() {
+			iterErr = err
+			cancel()
+		})
+	}
+	doForFunc := func(pos int) {
+		defer func() {
+			if r := recover(); r != nil {
+				setErr(itferr.NewMapItfErr(fmt.Sprintf("%s#ParallelForEach(%d)", b.Class, items[pos].idx), itferr.UnrecoverablePanicErr, fmt.Sprintf("%v", r), nil))
+			}
+		}()
+		key, val := forFunc(items[pos].idx, items[pos].key, items[pos].val)
+		results[pos] = forResult{key: key, val: val}
+	}
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for pos := range jobs {
+				doForFunc(pos)
+			}
+		}()
+	}
+
+dispatch:
+	for pos := range items {
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case jobs <- pos:
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if iterErr == nil && parentCtx.Err() != nil {
+		iterErr = itferr.NewMapItfErr(fmt.Sprintf("%s#ParallelForEach", b.Class), itferr.IterCanceled, "", parentCtx.Err())
+	}
+	if iterErr != nil {
+		b.ItfErr = iterErr
+		return NewExceptItfImplErr(b.ItfErr)
+	}
+
+	resultList := make([]interface{}, 0, len(results))
+	resultMap := make(map[interface{}]interface{}, len(results))
+	for _, rst := range results {
+		if rst.key == nil && rst.val == nil {
+			continue
+		}
+		if rst.key != nil {
+			resultMap[rst.key] = rst.val
+		} else {
+			resultList = append(resultList, rst.val)
+		}
+	}
+	return NewForeachItfImpl(b.Ctx, resultList, resultMap).WithIterChain(b.IterChain)
+}
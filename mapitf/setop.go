@@ -0,0 +1,96 @@
+package mapitf
+
+import (
+	"fmt"
+	"github.com/runingriver/mapinterface/api"
+	"github.com/runingriver/mapinterface/itferr"
+	"github.com/runingriver/mapinterface/pkg"
+)
+
+func (b *BaseItfImpl) Union(other interface{}) api.MapInterface {
+	return b.setOperate("Union", other, func(inLeft, inRight bool) bool {
+		return true
+	})
+}
+
+func (b *BaseItfImpl) Intersect(other interface{}) api.MapInterface {
+	return b.setOperate("Intersect", other, func(inLeft, inRight bool) bool {
+		return inLeft && inRight
+	})
+}
+
+func (b *BaseItfImpl) Difference(other interface{}) api.MapInterface {
+	return b.setOperate("Difference", other, func(inLeft, inRight bool) bool {
+		return inLeft && !inRight
+	})
+}
+
+func (b *BaseItfImpl) SymmetricDifference(other interface{}) api.MapInterface {
+	return b.setOperate("SymmetricDifference", other, func(inLeft, inRight bool) bool {
+		return inLeft != inRight
+	})
+}
+
+func (b *BaseItfImpl) Contains(val interface{}) (bool, error) {
+	if b.ItfErr != nil {
+		return false, b.ItfErr
+	}
+	listItf, itfErr := b.listOpVal("Contains")
+	if itfErr != nil {
+		return false, itfErr
+	}
+
+	key := pkg.EqualKey(val)
+	for _, item := range listItf {
+		if pkg.EqualKey(item) == key {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// setOperate 依次遍历当前list与other的元素,按keep(是否在当前list中,是否在other中)决定元素是否保留,结果去重
+func (b *BaseItfImpl) setOperate(funcName string, other interface{}, keep func(inLeft, inRight bool) bool) api.MapInterface {
+	if b.ItfErr != nil {
+		return b
+	}
+	leftList, itfErr := b.listOpVal(funcName)
+	if itfErr != nil {
+		b.ItfErr = itfErr
+		return b
+	}
+	rightList, err := Fr(b.Ctx, other).ToList()
+	if err != nil {
+		b.ItfErr = itferr.NewConvFailedX(fmt.Sprintf("%s#%s", b.Class, funcName), "other val is not list", err)
+		return b
+	}
+
+	leftKeys := make([]string, len(leftList))
+	leftSet := make(map[string]bool, len(leftList))
+	for i, item := range leftList {
+		leftKeys[i] = pkg.EqualKey(item)
+		leftSet[leftKeys[i]] = true
+	}
+	rightKeys := make([]string, len(rightList))
+	rightSet := make(map[string]bool, len(rightList))
+	for i, item := range rightList {
+		rightKeys[i] = pkg.EqualKey(item)
+		rightSet[rightKeys[i]] = true
+	}
+
+	result := make([]interface{}, 0, len(leftList))
+	visited := make(map[string]bool, len(leftList)+len(rightList))
+	for i, item := range leftList {
+		if key := leftKeys[i]; !visited[key] && keep(true, rightSet[key]) {
+			visited[key] = true
+			result = append(result, item)
+		}
+	}
+	for i, item := range rightList {
+		if key := rightKeys[i]; !visited[key] && !leftSet[key] && keep(false, true) {
+			visited[key] = true
+			result = append(result, item)
+		}
+	}
+	return NewForeachListItfImpl(b.Ctx, result).WithIterChain(b.IterChain)
+}
@@ -128,6 +128,12 @@ func (m *MapStrItfImpl) toMapStrInterface(v interface{}) map[string]interface{}
 		return result
 	}
 
+	if pkg.IsStruct(v) {
+		if result, err := pkg.StructToMap(v); err == nil {
+			return result
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,155 @@
+package mapitf
+
+import (
+	"fmt"
+	"github.com/runingriver/mapinterface/api"
+	"github.com/runingriver/mapinterface/conf"
+	"github.com/runingriver/mapinterface/itferr"
+	"github.com/runingriver/mapinterface/logx"
+	"github.com/runingriver/mapinterface/pkg"
+	"reflect"
+)
+
+// ToStructList 将list(含json list str,[]map[string]interface{})的每个元素按ToStruct的规则转换,结果写入out并返回out
+// out必须是*[]T或*[]*T;元素转换失败时错误中包含元素下标及字段路径,按SkipCvtFailForToArrayType决定跳过或返回错误
+func (b *BaseItfImpl) ToStructList(out interface{}, opt ...api.StructOpt) (interface{}, error) {
+	if b.ItfErr != nil {
+		return nil, b.ItfErr
+	}
+
+	rfOut := reflect.ValueOf(out)
+	if rfOut.Kind() != reflect.Ptr || rfOut.IsNil() || rfOut.Elem().Kind() != reflect.Slice {
+		return nil, itferr.NewParamTypeErr(fmt.Sprintf("%s#ToStructList(%T)", b.Class, out))
+	}
+	elemType := rfOut.Elem().Type().Elem()
+
+	listItf, err := b.ToList()
+	if err != nil {
+		return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToStructList", b.Class), "val is not list", err)
+	}
+
+	result := reflect.MakeSlice(rfOut.Elem().Type(), 0, len(listItf))
+	for i, v := range listItf {
+		elem, cvtErr := toStructElem(v, elemType, structTagName(opt))
+		if cvtErr != nil {
+			if conf.CONF.SkipCvtFailForToArrayType {
+				continue
+			}
+			return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToStructList(%d)", b.Class, i), "list val cannot cvt to struct", cvtErr)
+		}
+		result = reflect.Append(result, elem)
+	}
+	if result.Len() != len(listItf) {
+		logx.CtxWarn(b.Ctx, "ToStructList %d list val convert failed", len(listItf)-result.Len())
+	}
+	rfOut.Elem().Set(result)
+	return out, nil
+}
+
+// ToStructMap 将map(含json map str)的每个value按ToStruct的规则转换,key按pkg.ConvertTo转换为K,结果写入out并返回out
+// out必须是*map[K]T或*map[K]*T;转换失败时错误中包含key及字段路径,按SkipCvtFailForToMapType决定跳过或返回错误
+func (b *BaseItfImpl) ToStructMap(out interface{}, opt ...api.StructOpt) (interface{}, error) {
+	if b.ItfErr != nil {
+		return nil, b.ItfErr
+	}
+
+	rfOut := reflect.ValueOf(out)
+	if rfOut.Kind() != reflect.Ptr || rfOut.IsNil() || rfOut.Elem().Kind() != reflect.Map {
+		return nil, itferr.NewParamTypeErr(fmt.Sprintf("%s#ToStructMap(%T)", b.Class, out))
+	}
+	mapType := rfOut.Elem().Type()
+
+	src := pkg.Interpret(b.IterVal)
+	if isJson, jsonStr := pkg.JsonChecker(src); isJson {
+		rstMap, err := pkg.JsonLoadsMap(jsonStr)
+		if err != nil {
+			return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToStructMap", b.Class), "not json map str", err)
+		}
+		src = rstMap
+	}
+	rfSrc := reflect.ValueOf(src)
+	if rfSrc.Kind() != reflect.Map {
+		return nil, itferr.NewConvFailed(fmt.Sprintf("%s#ToStructMap", b.Class))
+	}
+
+	result := reflect.MakeMapWithSize(mapType, rfSrc.Len())
+	iter := rfSrc.MapRange()
+	for iter.Next() {
+		key, cvtErr := pkg.ConvertToX(iter.Key().Interface(), mapType.Key(), conf.IsStrictNumberCvt(b.Ctx))
+		var elem reflect.Value
+		if cvtErr == nil {
+			elem, cvtErr = toStructElem(iter.Value().Interface(), mapType.Elem(), structTagName(opt))
+		}
+		if cvtErr != nil {
+			if conf.CONF.SkipCvtFailForToMapType {
+				continue
+			}
+			return nil, itferr.NewConvFailedX(fmt.Sprintf("%s#ToStructMap(%v)", b.Class, iter.Key().Interface()), "map val cannot cvt to struct", cvtErr)
+		}
+		result.SetMapIndex(key, elem)
+	}
+	if result.Len() != rfSrc.Len() {
+		logx.CtxWarn(b.Ctx, "ToStructMap %d key value convert failed", rfSrc.Len()-result.Len())
+	}
+	rfOut.Elem().Set(result)
+	return out, nil
+}
+
+// toStructElem 将v转换为typ(struct或struct指针),规则同decodeToStruct
+func toStructElem(v interface{}, typ reflect.Type, tagName string) (reflect.Value, error) {
+	if v != nil && reflect.TypeOf(v).AssignableTo(typ) {
+		return reflect.ValueOf(v), nil
+	}
+	structType := typ
+	if typ.Kind() == reflect.Ptr {
+		structType = typ.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("%v is not struct", typ)
+	}
+
+	v = pkg.Interpret(v)
+	dst := reflect.New(structType)
+	if v != nil && reflect.TypeOf(v) == structType {
+		dst.Elem().Set(reflect.ValueOf(v))
+	} else if _, err := decodeToStruct(v, dst.Interface(), tagName); err != nil {
+		return reflect.Value{}, err
+	}
+
+	if typ.Kind() == reflect.Ptr {
+		return dst, nil
+	}
+	return dst.Elem(), nil
+}
+
+// decodeToStruct 将v解码到out(指针):tagName为空时json str使用json tag解码,map/struct使用mapstructure tag弱类型解码;
+// tagName不为空时json str先解析为map,所有来源均按tagName以mapstructure弱类型解码
+func decodeToStruct(v interface{}, out interface{}, tagName string) (interface{}, error) {
+	v = pkg.Interpret(v)
+	if isJson, js := pkg.JsonChecker(v); isJson {
+		if tagName == "" {
+			return pkg.JsonLoadsObj(js, out)
+		}
+		mapObj, err := pkg.JsonLoadsMap(js)
+		if err != nil {
+			return nil, err
+		}
+		v = mapObj
+	}
+
+	if v == nil {
+		return nil, fmt.Errorf("nil cannot cvt to %T", out)
+	}
+	switch reflect.TypeOf(v).Kind() {
+	case reflect.Map, reflect.Struct:
+		return pkg.DecodeStruct(v, out, tagName)
+	}
+	return nil, fmt.Errorf("%T cannot cvt to %T", v, out)
+}
+
+func structTagName(opt []api.StructOpt) string {
+	if len(opt) > 0 {
+		return opt[0].TagName
+	}
+	return ""
+}
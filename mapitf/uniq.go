@@ -0,0 +1,146 @@
+package mapitf
+
+import (
+	"context"
+	"fmt"
+	"github.com/runingriver/mapinterface/api"
+	"github.com/runingriver/mapinterface/conf"
+	"github.com/runingriver/mapinterface/itferr"
+	"github.com/runingriver/mapinterface/logx"
+	"github.com/runingriver/mapinterface/pkg"
+	"reflect"
+	"strings"
+)
+
+// UniqBy 元素在path处取不到值时返回错误,SkipCvtFailForToArrayType为true时丢弃该元素
+func (b *BaseItfImpl) UniqBy(path interface{}, opt ...api.UniqOpt) api.MapInterface {
+	keys, ok := path.([]interface{})
+	if !ok {
+		keys = []interface{}{path}
+	}
+	return b.uniqBy(fmt.Sprintf("UniqBy(%v)", path), func(i int, v interface{}) (interface{}, error) {
+		return getByPath(b.Ctx, v, keys)
+	}, opt...)
+}
+
+func (b *BaseItfImpl) UniqByFunc(fn api.UniqKeyFunc, opt ...api.UniqOpt) api.MapInterface {
+	return b.uniqBy("UniqByFunc", func(i int, v interface{}) (interface{}, error) {
+		return fn(i, v), nil
+	}, opt...)
+}
+
+func (b *BaseItfImpl) UniqDeep(opt ...api.UniqOpt) api.MapInterface {
+	return b.uniqBy("UniqDeep", func(i int, v interface{}) (interface{}, error) {
+		return v, nil
+	}, opt...)
+}
+
+// uniqBy 按keyFunc返回值的NormKey去重,结果保持元素原有的相对顺序;原list为切片时结果与原list类型一致
+func (b *BaseItfImpl) uniqBy(funcName string, keyFunc func(i int, v interface{}) (interface{}, error), opt ...api.UniqOpt) api.MapInterface {
+	if b.ItfErr != nil {
+		return b
+	}
+
+	uniqOpt := api.UniqOpt{}
+	if len(opt) > 0 {
+		uniqOpt = opt[0]
+	}
+
+	val := b.IterVal
+	if isJson, js := pkg.JsonChecker(val); isJson {
+		listObj, err := pkg.JsonLoadsList(js)
+		if err != nil {
+			b.ItfErr = itferr.NewConvFailedX(fmt.Sprintf("%s#%s", b.Class, funcName), "not json list str", err)
+			return b
+		}
+		b.IterChain.ReplaceBack(listObj)
+		val = listObj
+	}
+	rfV := pkg.ReflectToVal(val)
+	if rfV.Kind() != reflect.Slice && rfV.Kind() != reflect.Array {
+		b.ItfErr = itferr.NewFuncUsedErr(fmt.Sprintf("%s#%s", b.Class, funcName), "val is not list")
+		return b
+	}
+
+	keyPos := make(map[string]int, rfV.Len()) // key => 保留元素的索引
+	keep := make([]bool, rfV.Len())
+	skipCnt := 0
+	for i := 0; i < rfV.Len(); i++ {
+		if !rfV.Index(i).CanInterface() {
+			continue
+		}
+		key, err := keyFunc(i, rfV.Index(i).Interface())
+		if err != nil {
+			if conf.CONF.SkipCvtFailForToArrayType {
+				skipCnt++
+				continue
+			}
+			b.ItfErr = itferr.NewConvFailedX(fmt.Sprintf("%s#%s(%d)", b.Class, funcName, i), "get uniq key err", err)
+			return b
+		}
+
+		normKey := pkg.NormKey(key)
+		if pos, ok := keyPos[normKey]; ok {
+			if !uniqOpt.KeepLast {
+				continue
+			}
+			keep[pos] = false
+		}
+		keyPos[normKey] = i
+		keep[i] = true
+	}
+	if skipCnt > 0 {
+		logx.CtxWarn(b.Ctx, "%s %d list val get uniq key failed", funcName, skipCnt)
+	}
+
+	sliceType := rfV.Type()
+	if rfV.Kind() == reflect.Array {
+		sliceType = reflect.SliceOf(sliceType.Elem())
+	}
+	result := reflect.MakeSlice(sliceType, 0, len(keyPos))
+	for i, ok := range keep {
+		if ok {
+			result = reflect.Append(result, rfV.Index(i))
+		}
+	}
+	b.IterVal = result.Interface()
+	return b
+}
+
+// getByPath 按path逐层取值,struct按字段名或json tag取值,其他按GetAny取值
+func getByPath(ctx context.Context, v interface{}, path []interface{}) (interface{}, error) {
+	for _, key := range path {
+		rfV := pkg.ReflectToVal(v)
+		if rfV.Kind() != reflect.Struct {
+			var err error
+			if v, err = Fr(ctx, v).GetAny(key).Val(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		fieldV, ok := structFieldByName(rfV, pkg.ToStr(key))
+		if !ok {
+			return nil, fmt.Errorf("struct field %v not found", key)
+		}
+		v = fieldV
+	}
+	return v, nil
+}
+
+// structFieldByName 按字段名或json tag查找可导出字段的值
+func structFieldByName(rfV reflect.Value, name string) (interface{}, bool) {
+	if field, ok := rfV.Type().FieldByName(name); ok && field.IsExported() {
+		return rfV.FieldByIndex(field.Index).Interface(), true
+	}
+	for i := 0; i < rfV.NumField(); i++ {
+		field := rfV.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if tagName := strings.Split(field.Tag.Get("json"), ",")[0]; tagName == name {
+			return rfV.Field(i).Interface(), true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,142 @@
+package mapitf
+
+import (
+	"fmt"
+	"github.com/runingriver/mapinterface/api"
+	"github.com/runingriver/mapinterface/itferr"
+	"github.com/runingriver/mapinterface/pkg"
+	"reflect"
+	"sort"
+)
+
+// Walk 替换值时要求父节点的元素类型可接收新值(如map[string]interface{},[]interface{}),否则返回SetValueErr
+func (b *BaseItfImpl) Walk(fn api.WalkFunc, opt ...api.WalkOpt) api.MapInterface {
+	if b.ItfErr != nil {
+		return b
+	}
+
+	w := &walker{class: b.Class, fn: fn}
+	if len(opt) > 0 {
+		w.opt = opt[0]
+	}
+
+	val := b.IterVal
+	if isJson, js := pkg.JsonChecker(val); isJson {
+		if jsonVal, ok := loadsJsonContainer(js); ok {
+			val = jsonVal
+			b.IterVal = jsonVal
+			b.IterChain.ReplaceBack(jsonVal)
+		}
+	}
+
+	newVal, changed, _ := w.walk(val, []interface{}{}, 0, nil)
+	if w.err != nil {
+		b.ItfErr = w.err
+		return b
+	}
+	if changed {
+		b.IterVal = newVal
+		b.IterChain.ReplaceBack(newVal)
+	}
+	return b
+}
+
+type walker struct {
+	class string
+	fn    api.WalkFunc
+	opt   api.WalkOpt
+	err   itferr.MapItfErr
+}
+
+// walk 返回当前节点的新值,是否需要父节点重新赋值,是否结束遍历
+func (w *walker) walk(val interface{}, path []interface{}, depth int, parent interface{}) (interface{}, bool, bool) {
+	fromJson := false
+	if w.opt.DescendJsonStr && depth > 0 {
+		if isJson, js := pkg.JsonChecker(val); isJson {
+			if jsonVal, ok := loadsJsonContainer(js); ok {
+				val, fromJson = jsonVal, true
+			}
+		}
+	}
+
+	newVal, action := w.fn(api.WalkNode{Path: path, Val: val, Depth: depth, Parent: parent})
+	switch action {
+	case api.WalkStop:
+		return val, false, true
+	case api.WalkSkip:
+		return val, false, false
+	case api.WalkReplace:
+		return newVal, true, false
+	}
+
+	childChanged := false
+	rfV := pkg.ReflectToVal(val)
+	switch rfV.Kind() {
+	case reflect.Map:
+		mapKeys := rfV.MapKeys()
+		sort.SliceStable(mapKeys, func(i, j int) bool {
+			return pkg.KeyLess(mapKeys[i].Interface(), mapKeys[j].Interface())
+		})
+		for _, rfK := range mapKeys {
+			mpV := rfV.MapIndex(rfK)
+			if !rfK.CanInterface() || !mpV.IsValid() || !mpV.CanInterface() {
+				continue
+			}
+			childPath := append(path[:len(path):len(path)], rfK.Interface())
+			childVal, changed, stop := w.walk(mpV.Interface(), childPath, depth+1, val)
+			if changed {
+				setV, ok := walkAssignable(rfV.Type().Elem(), childVal)
+				if !ok {
+					w.err = itferr.NewSetValueErr(fmt.Sprintf("%s#Walk(%v)", w.class, childPath), "map val type mismatch", nil)
+					return val, false, true
+				}
+				rfV.SetMapIndex(rfK, setV)
+				childChanged = true
+			}
+			if stop {
+				return val, childChanged && fromJson, true
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if _, isByte := val.([]byte); isByte {
+			break
+		}
+		for i := 0; i < rfV.Len(); i++ {
+			idxV := rfV.Index(i)
+			if !idxV.IsValid() || !idxV.CanInterface() {
+				continue
+			}
+			childPath := append(path[:len(path):len(path)], i)
+			childVal, changed, stop := w.walk(idxV.Interface(), childPath, depth+1, val)
+			if changed {
+				setV, ok := walkAssignable(rfV.Type().Elem(), childVal)
+				if !ok || !idxV.CanSet() {
+					w.err = itferr.NewSetValueErr(fmt.Sprintf("%s#Walk(%v)", w.class, childPath), "list val type mismatch or un-settable", nil)
+					return val, false, true
+				}
+				idxV.Set(setV)
+				childChanged = true
+			}
+			if stop {
+				return val, childChanged && fromJson, true
+			}
+		}
+	}
+	return val, childChanged && fromJson, false
+}
+
+// walkAssignable val能否赋值给typ类型的元素
+func walkAssignable(typ reflect.Type, val interface{}) (reflect.Value, bool) {
+	if val == nil {
+		switch typ.Kind() {
+		case reflect.Interface, reflect.Map, reflect.Slice, reflect.Ptr:
+			return reflect.Zero(typ), true
+		}
+		return reflect.Value{}, false
+	}
+	rfV := reflect.ValueOf(val)
+	if !rfV.Type().AssignableTo(typ) {
+		return reflect.Value{}, false
+	}
+	return rfV, true
+}
@@ -0,0 +1,146 @@
+package pkg
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// maxDecimalExp 十进制指数的绝对值上限,避免"1e999999999"这类输入构造出超大的数
+const maxDecimalExp = 10000
+
+var decimalRegex = regexp.MustCompile(`^[+-]?(\d+\.?\d*|\.\d+)([eE]([+-]?\d+))?$`)
+
+// ToBigRat 将v精确转换为*big.Rat,json.Number及字符串按原始文本解析,不经过float64
+// 支持如"123","-1.25","1.5e3"的十进制文本;float32/float64按其最短十进制表示转换
+func ToBigRat(v interface{}) (*big.Rat, error) {
+	v = Interpret(v)
+	switch vv := v.(type) {
+	case *big.Rat:
+		return new(big.Rat).Set(vv), nil
+	case big.Rat:
+		return new(big.Rat).Set(&vv), nil
+	case *big.Int:
+		return new(big.Rat).SetInt(vv), nil
+	case big.Int:
+		return new(big.Rat).SetInt(&vv), nil
+	case *big.Float:
+		if vv.IsInf() {
+			return nil, errors.New("inf cannot convert to big.Rat")
+		}
+		r, _ := vv.Rat(nil)
+		return r, nil
+	}
+
+	s, err := decimalText(v)
+	if err != nil {
+		return nil, err
+	}
+	m := decimalRegex.FindStringSubmatch(s)
+	if m == nil {
+		return nil, fmt.Errorf("%s is not a decimal number", s)
+	}
+	if m[3] != "" {
+		exp, expErr := strconv.Atoi(m[3])
+		if expErr != nil || exp > maxDecimalExp || exp < -maxDecimalExp {
+			return nil, fmt.Errorf("%s exponent out of range", s)
+		}
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a decimal number", s)
+	}
+	return r, nil
+}
+
+// ToBigInt 将v精确转换为*big.Int,如"1.5e3"转为1500;含小数部分时与ToInt64一致向0截断
+func ToBigInt(v interface{}) (*big.Int, error) {
+	switch vv := Interpret(v).(type) {
+	case *big.Int:
+		return new(big.Int).Set(vv), nil
+	case big.Int:
+		return new(big.Int).Set(&vv), nil
+	}
+
+	r, err := ToBigRat(v)
+	if err != nil {
+		return nil, err
+	}
+	if r.IsInt() {
+		return new(big.Int).Set(r.Num()), nil
+	}
+	return new(big.Int).Quo(r.Num(), r.Denom()), nil
+}
+
+// ToBigFloat 将v转换为*big.Float,精度取max(64,分子分母的位数),使十进制文本的有效数字不被截断
+func ToBigFloat(v interface{}) (*big.Float, error) {
+	switch vv := Interpret(v).(type) {
+	case *big.Float:
+		return new(big.Float).Copy(vv), nil
+	case big.Float:
+		return new(big.Float).Copy(&vv), nil
+	}
+
+	r, err := ToBigRat(v)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Float).SetRat(r), nil
+}
+
+// ToDecimalStr 将v转换为保留scale位小数的十进制字符串,超出部分四舍五入(0.5远离0),如("1.005", 2)转为"1.01"
+// 全程基于big.Rat计算,适用于金额等不能有精度损失的场景
+func ToDecimalStr(v interface{}, scale int) (string, error) {
+	if scale < 0 {
+		return "", fmt.Errorf("scale %d must not be negative", scale)
+	}
+	r, err := ToBigRat(v)
+	if err != nil {
+		return "", err
+	}
+	return r.FloatString(scale), nil
+}
+
+// decimalText 获取v的十进制文本表示
+func decimalText(v interface{}) (string, error) {
+	if cv, ok, err := customNumber(v); ok {
+		if err != nil {
+			return "", err
+		}
+		v = Interpret(cv)
+	}
+	switch vv := v.(type) {
+	case nil:
+		return "", errors.New("nil cannot convert to number")
+	case json.Number:
+		return strings.TrimSpace(vv.String()), nil
+	case string:
+		return normNumStr(strings.TrimSpace(vv)), nil
+	case []byte:
+		return normNumStr(strings.TrimSpace(string(vv))), nil
+	case float32:
+		return strconv.FormatFloat(float64(vv), 'g', -1, 32), nil
+	case float64:
+		return strconv.FormatFloat(vv, 'g', -1, 64), nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, 32), nil
+	case reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, 64), nil
+	case reflect.String:
+		return strings.TrimSpace(rv.String()), nil
+	}
+	return "", fmt.Errorf("%T cannot convert to number", v)
+}
@@ -9,7 +9,11 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/runingriver/mapinterface/conf"
 	"github.com/runingriver/mapinterface/itferr"
+	"github.com/runingriver/mapinterface/logx"
+	"math"
+	"math/big"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"unsafe"
@@ -87,15 +91,203 @@ func JsonChecker(v interface{}) (bool, string) {
 	return false, ""
 }
 
+// JsonKeyOrder 返回jsonStr中按path定位到的json map的key在原文中出现的顺序,path中int为list索引,其余为map key
+// 注:path途经的值是json字符串时,会继续在该字符串内查找
+func JsonKeyOrder(jsonStr string, path ...interface{}) ([]string, error) {
+	dc := json.NewDecoder(strings.NewReader(jsonStr))
+	dc.UseNumber()
+
+	for pi, p := range path {
+		tok, err := dc.Token()
+		if err != nil {
+			return nil, err
+		}
+		if s, ok := tok.(string); ok {
+			return JsonKeyOrder(s, path[pi:]...)
+		}
+
+		found := false
+		switch tok {
+		case json.Delim('{'):
+			key := ToStr(p)
+			for !found && dc.More() {
+				kTok, err := dc.Token()
+				if err != nil {
+					return nil, err
+				}
+				if found = kTok == key; !found {
+					if err = skipJsonValue(dc); err != nil {
+						return nil, err
+					}
+				}
+			}
+		case json.Delim('['):
+			idx, ok := p.(int)
+			for i := 0; ok && !found && dc.More(); i++ {
+				if found = i == idx; !found {
+					if err = skipJsonValue(dc); err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("path %v not found", path[:pi+1])
+		}
+	}
+
+	tok, err := dc.Token()
+	if err != nil {
+		return nil, err
+	}
+	if s, ok := tok.(string); ok {
+		return JsonKeyOrder(s)
+	}
+	if tok != json.Delim('{') {
+		return nil, errors.New("json value is not map")
+	}
+	keys := make([]string, 0)
+	for dc.More() {
+		kTok, err := dc.Token()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, kTok.(string))
+		if err = skipJsonValue(dc); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+// skipJsonValue 跳过dc中的下一个完整的json值
+func skipJsonValue(dc *json.Decoder) error {
+	depth := 0
+	for {
+		tok, err := dc.Token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+// KeyLess map key的默认排序规则:均为数字时按数值比较,否则按ToStr后的字符串比较
+func KeyLess(k1, k2 interface{}) bool {
+	if isNumber(k1) && isNumber(k2) {
+		f1, err1 := ToFloat64(k1)
+		f2, err2 := ToFloat64(k2)
+		if err1 == nil && err2 == nil && f1 != f2 {
+			return f1 < f2
+		}
+	}
+	return ToStr(k1) < ToStr(k2)
+}
+
+func isNumber(v interface{}) bool {
+	switch Interpret(v).(type) {
+	case json.Number, int8, int16, int32, int, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return true
+	}
+	return false
+}
+
 func MapToStruct(inputMap interface{}, outputStruct interface{}) (interface{}, error) {
 	if t := reflect.TypeOf(inputMap); t.Kind() != reflect.Map {
 		return nil, errors.New("param inputMap must be map")
 	}
-	if t := reflect.TypeOf(outputStruct); t.Kind() != reflect.Ptr {
+	return DecodeStruct(inputMap, outputStruct, "")
+}
+
+// DecodeStruct 使用mapstructure弱类型解码,input可以是map或struct,tagName为空时使用mapstructure tag
+// 字段转换失败时返回*itferr.StructFieldErr,包含所有失败字段的路径及原因
+func DecodeStruct(input interface{}, outputStruct interface{}, tagName string) (interface{}, error) {
+	if t := reflect.TypeOf(outputStruct); t == nil || t.Kind() != reflect.Ptr {
 		return nil, errors.New("param outputStruct must be ptr")
 	}
-	err := mapstructure.WeakDecode(inputMap, outputStruct)
-	return outputStruct, err
+	rec := &fieldErrRecorder{tagName: tagName, md: &mapstructure.Metadata{}}
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook:       rec.hook,
+		Metadata:         rec.md,
+		TagName:          tagName,
+		WeaklyTypedInput: true,
+		Result:           outputStruct,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err = decoder.Decode(input); err != nil {
+		return outputStruct, rec.structFieldErr(err)
+	}
+	return outputStruct, nil
+}
+
+// fieldErrRecorder 通过DecodeHook在解码每个值之前先试解码,记录失败的值;
+// mapstructure解码完一个值后将其路径追加到Metadata.Keys,失败的值不会再解码子元素,所以其路径即为试解码时Keys末尾的下一个
+type fieldErrRecorder struct {
+	tagName string
+	md      *mapstructure.Metadata
+	fails   []fieldFail
+}
+
+type fieldFail struct {
+	keyIdx int
+	from   reflect.Value
+	to     reflect.Type
+}
+
+func (r *fieldErrRecorder) hook(from reflect.Value, to reflect.Value) (interface{}, error) {
+	if err := r.tryDecode(from, to.Type()); err != nil {
+		r.fails = append(r.fails, fieldFail{keyIdx: len(r.md.Keys), from: from, to: to.Type()})
+	}
+	return from.Interface(), nil
+}
+
+// tryDecode 将from试解码为to类型的新值,只校验当前层级,子元素以零值代替,由各自的hook校验
+func (r *fieldErrRecorder) tryDecode(from reflect.Value, to reflect.Type) error {
+	root := true
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook: func(f reflect.Value, t reflect.Value) (interface{}, error) {
+			if root {
+				root = false
+				return f.Interface(), nil
+			}
+			return reflect.Zero(t.Type()).Interface(), nil
+		},
+		TagName:          r.tagName,
+		WeaklyTypedInput: true,
+		Result:           reflect.New(to).Interface(),
+	})
+	if err != nil {
+		return err
+	}
+	return decoder.Decode(from.Interface())
+}
+
+// structFieldErr 将记录的失败值转换为字段级别的错误,未能定位到字段时返回mapstructure的原始错误信息
+func (r *fieldErrRecorder) structFieldErr(err error) error {
+	if len(r.fails) == 0 {
+		return &itferr.StructFieldErr{Fields: []itferr.FieldErr{{Reason: err.Error()}}}
+	}
+	sfe := &itferr.StructFieldErr{Fields: make([]itferr.FieldErr, 0, len(r.fails))}
+	for _, f := range r.fails {
+		fe := itferr.FieldErr{}
+		if f.keyIdx < len(r.md.Keys) {
+			fe.Path = r.md.Keys[f.keyIdx]
+		}
+		fe.Reason = fmt.Sprintf("'%s' expected type '%s', got '%s', value: '%v'", fe.Path, f.to, f.from.Type(), f.from.Interface())
+		sfe.Fields = append(sfe.Fields, fe)
+	}
+	sort.Slice(sfe.Fields, func(i, j int) bool { return sfe.Fields[i].Path < sfe.Fields[j].Path })
+	return sfe
 }
 
 func IsStrType(v interface{}) (bool, string) {
@@ -114,13 +306,40 @@ func IsStrType(v interface{}) (bool, string) {
 	}
 }
 
+// ToStr 优先使用注册的自定义转换函数,其返回错误时记录日志并按内置规则转换;需要获取该错误时使用ToStrE
 func ToStr(v interface{}) string {
+	if cv, ok, err := customCvt(v, reflect.String); ok {
+		if err == nil {
+			return toStr(cv)
+		}
+		logx.Warn("ToStr %T use custom converter failed, fallback to built-in rules: %v", v, err)
+	}
+	return toStr(v)
+}
+
+// ToStrE 同ToStr,注册的自定义转换函数返回错误时直接返回该错误
+func ToStrE(v interface{}) (string, error) {
+	if cv, ok, err := customCvt(v, reflect.String); ok {
+		if err != nil {
+			return "", err
+		}
+		return toStr(cv), nil
+	}
+	return toStr(v), nil
+}
+
+func toStr(v interface{}) string {
 	result := ""
 	if v == nil {
 		return result
 	}
 	switch vv := v.(type) {
 	case json.Number:
+		if nf := conf.CONF.NumberFmt; nf.JsonNumberAsFloat {
+			if f, err := vv.Float64(); err == nil {
+				return FormatFloat(f, 64, nf)
+			}
+		}
 		result = vv.String()
 	case string:
 		result = vv
@@ -145,9 +364,9 @@ func ToStr(v interface{}) string {
 	case uint64:
 		return strconv.FormatUint(vv, 10)
 	case float32:
-		return strconv.FormatFloat(float64(vv), 'G', -1, 32)
+		return FormatFloat(float64(vv), 32, conf.CONF.NumberFmt)
 	case float64:
-		return strconv.FormatFloat(vv, 'G', -1, 64)
+		return FormatFloat(vv, 64, conf.CONF.NumberFmt)
 	case bool:
 		result = strconv.FormatBool(vv)
 	case []byte:
@@ -174,6 +393,37 @@ func ToStr(v interface{}) string {
 	return result
 }
 
+// FormatFloat 按nf格式化f,bitSize为32或64;ToStr使用conf.CONF.NumberFmt,单次调用需要其他格式时可直接使用
+func FormatFloat(f float64, bitSize int, nf conf.NumberFmt) string {
+	fmtByte := nf.FloatFmt
+	if fmtByte == 0 {
+		fmtByte = 'G'
+	}
+	prec := -1
+	if nf.Prec != nil {
+		prec = *nf.Prec
+	}
+	result := strconv.FormatFloat(f, fmtByte, prec, bitSize)
+	if nf.TrimZero {
+		result = trimFloatZero(result)
+	}
+	return result
+}
+
+// trimFloatZero 去掉小数部分末尾的0及小数点,指数部分保持不变,如:1.500->1.5,2.00E+10->2E+10
+func trimFloatZero(s string) string {
+	mantissa, exp := s, ""
+	if i := strings.IndexAny(s, "eEpP"); i >= 0 {
+		mantissa, exp = s[:i], s[i:]
+	}
+	if !strings.Contains(mantissa, ".") {
+		return s
+	}
+	mantissa = strings.TrimRight(mantissa, "0")
+	mantissa = strings.TrimSuffix(mantissa, ".")
+	return mantissa + exp
+}
+
 func CallMethod(v interface{}, methodName string) (interface{}, bool) {
 	var ptr reflect.Value
 	var value reflect.Value
@@ -212,6 +462,12 @@ func CallMethod(v interface{}, methodName string) (interface{}, bool) {
 
 // ToInt64 如果v是float或uint,会进行强转,uint超过int64的最大数值可能丢失精度
 func ToInt64(v interface{}) (int64, error) {
+	if cv, ok, err := customCvt(v, reflect.Int64); ok {
+		if err != nil {
+			return 0, itferr.NewBaseTypeConvErr("ToInt64", "", err)
+		}
+		v = cv
+	}
 	v = Interpret(v)
 	switch vv := v.(type) {
 	case json.Number:
@@ -280,6 +536,7 @@ func ToInt64(v interface{}) (int64, error) {
 }
 
 func strToInt64(vv string) (int64, error) {
+	vv = normNumStr(vv)
 	if strings.ContainsAny(vv, ".e") {
 		result, convErr := strconv.ParseFloat(vv, 64)
 		if convErr != nil {
@@ -296,6 +553,12 @@ func strToInt64(vv string) (int64, error) {
 
 // ToFloat64 如果v是int64或uint64,会进行强转,数字超过float64最大数值可能丢失精度
 func ToFloat64(v interface{}) (float64, error) {
+	if cv, ok, err := customCvt(v, reflect.Float64); ok {
+		if err != nil {
+			return 0, itferr.NewBaseTypeConvErr("ToFloat64", "", err)
+		}
+		v = cv
+	}
 	v = Interpret(v)
 	switch vv := v.(type) {
 	case json.Number:
@@ -313,13 +576,13 @@ func ToFloat64(v interface{}) (float64, error) {
 			}
 		}
 	case string:
-		result, convErr := strconv.ParseFloat(vv, 64)
+		result, convErr := strconv.ParseFloat(normNumStr(vv), 64)
 		if convErr != nil {
 			return 0, itferr.NewBaseTypeConvErr("ToFloat64#ParseFloat()", "", convErr)
 		}
 		return result, nil
 	case []byte:
-		result, convErr := strconv.ParseFloat(string(vv), 64)
+		result, convErr := strconv.ParseFloat(normNumStr(string(vv)), 64)
 		if convErr != nil {
 			return 0, fmt.Errorf("ToFloat64 convert err:%v", convErr)
 		}
@@ -379,6 +642,49 @@ func ToFloat32(v interface{}) (float32, error) {
 	return float32(k), nil
 }
 
+// ToBool bool直接返回;字符串按conf.CONF.BoolTrueStrs/BoolFalseStrs匹配(大小写不敏感);
+// 数字(含数字字符串,如:"1.0")同原有规则:取整后为1时为true,其他数字为false;conf.CONF.StrictBoolCvt为true时只接受0和1,其他数字返回错误
+func ToBool(v interface{}) (bool, error) {
+	if b, ok := v.(bool); ok {
+		return b, nil
+	}
+	if cv, ok, err := customCvt(v, reflect.Bool); ok {
+		if err != nil {
+			return false, err
+		}
+		v = cv
+	}
+	v = Interpret(v)
+	if b, ok := v.(bool); ok {
+		return b, nil
+	}
+
+	s := strings.TrimSpace(ToStr(v))
+	for _, t := range conf.CONF.BoolTrueStrs {
+		if strings.EqualFold(s, t) {
+			return true, nil
+		}
+	}
+	for _, f := range conf.CONF.BoolFalseStrs {
+		if strings.EqualFold(s, f) {
+			return false, nil
+		}
+	}
+
+	if conf.CONF.StrictBoolCvt {
+		f, err := ToFloat64(v)
+		if err != nil || (f != 0 && f != 1) {
+			return false, fmt.Errorf("%v cannot convert to bool in strict mode", v)
+		}
+		return f == 1, nil
+	}
+	i, err := ToInt64(v)
+	if err != nil {
+		return false, fmt.Errorf("%v cannot convert to bool", v)
+	}
+	return i == 1, nil
+}
+
 // StrToByte 高效转换,避免内存拷贝
 func StrToByte(s string) (b []byte) {
 	*(*string)(unsafe.Pointer(&b)) = s
@@ -618,3 +924,124 @@ func UniqByReflect(data interface{}) interface{} {
 
 	return outArr.Interface()
 }
+
+// NormKey 将v归一化为字符串,用于深度比较:数字按数值归一(json.Number("1")与1相同),map按key排序,指针取其指向的值
+func NormKey(v interface{}) string {
+	var sb strings.Builder
+	writeNormKey(&sb, v)
+	return sb.String()
+}
+
+func writeNormKey(sb *strings.Builder, v interface{}) {
+	v = Interpret(v)
+	if v == nil {
+		sb.WriteString("null")
+		return
+	}
+	switch vv := v.(type) {
+	case string:
+		sb.WriteString(strconv.Quote(vv))
+		return
+	case []byte:
+		sb.WriteString(strconv.Quote(ByteToStr(vv)))
+		return
+	case bool:
+		sb.WriteString(strconv.FormatBool(vv))
+		return
+	}
+	if isNumber(v) {
+		sb.WriteString(normNumber(v))
+		return
+	}
+
+	rfV := reflect.ValueOf(v)
+	switch rfV.Kind() {
+	case reflect.Map:
+		pairs := make([][2]string, 0, rfV.Len())
+		for _, rfK := range rfV.MapKeys() {
+			if !rfK.CanInterface() || !rfV.MapIndex(rfK).CanInterface() {
+				continue
+			}
+			pairs = append(pairs, [2]string{NormKey(rfK.Interface()), NormKey(rfV.MapIndex(rfK).Interface())})
+		}
+		sort.Slice(pairs, func(i, j int) bool {
+			return pairs[i][0] < pairs[j][0]
+		})
+		sb.WriteString("{")
+		for i, pair := range pairs {
+			if i > 0 {
+				sb.WriteString(",")
+			}
+			sb.WriteString(pair[0] + ":" + pair[1])
+		}
+		sb.WriteString("}")
+	case reflect.Slice, reflect.Array:
+		sb.WriteString("[")
+		for i := 0; i < rfV.Len(); i++ {
+			if i > 0 {
+				sb.WriteString(",")
+			}
+			if idxV := rfV.Index(i); idxV.CanInterface() {
+				writeNormKey(sb, idxV.Interface())
+			}
+		}
+		sb.WriteString("]")
+	case reflect.Struct:
+		sb.WriteString(rfV.Type().String() + "{")
+		for i := 0; i < rfV.NumField(); i++ {
+			if !rfV.Field(i).CanInterface() {
+				continue
+			}
+			sb.WriteString(rfV.Type().Field(i).Name + ":")
+			writeNormKey(sb, rfV.Field(i).Interface())
+			sb.WriteString(",")
+		}
+		sb.WriteString("}")
+	default:
+		sb.WriteString(fmt.Sprintf("%#v", v))
+	}
+}
+
+// EqualKey 宽松比较用的归一化key:基础类型按字符串比较(数字按数值),即"42"与42,json.Number("1")与1视为相同;其他类型同NormKey.
+// key带类别前缀,基础类型为"s:",其他为"c:",保证字符串`{"a":1}`与map{"a":1}不会相同
+func EqualKey(v interface{}) string {
+	v = Interpret(v)
+	switch {
+	case isNumber(v):
+		return "s:" + normNumber(v)
+	case IsBaseType(v):
+		return "s:" + ToStr(v)
+	}
+	return "c:" + NormKey(v)
+}
+
+// normNumber 整数值(含超出int64范围的)统一为精确的十进制整数形式,其他按float64的最短形式
+func normNumber(v interface{}) string {
+	switch vv := v.(type) {
+	case json.Number:
+		if i, err := vv.Int64(); err == nil {
+			return strconv.FormatInt(i, 10)
+		}
+		// 超出int64范围的整数文本(如:uint64的snowflake id)按精确值处理,不经float64
+		if i, ok := new(big.Int).SetString(string(vv), 10); ok {
+			return i.String()
+		}
+	case int8, int16, int32, int, int64:
+		return strconv.FormatInt(reflect.ValueOf(vv).Int(), 10)
+	case uint, uint8, uint16, uint32, uint64:
+		return strconv.FormatUint(reflect.ValueOf(vv).Uint(), 10)
+	}
+
+	f, err := ToFloat64(v)
+	if err != nil {
+		return ToStr(v)
+	}
+	if f == math.Trunc(f) && math.Abs(f) < 1<<63 {
+		return strconv.FormatInt(int64(f), 10)
+	}
+	if f == math.Trunc(f) && !math.IsInf(f, 0) {
+		i, _ := new(big.Float).SetFloat64(f).Int(nil)
+		return i.String()
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -2,7 +2,12 @@ package pkg
 
 import (
 	"encoding/json"
+	"github.com/runingriver/mapinterface/conf"
+	"github.com/runingriver/mapinterface/itferr"
+	"math"
+	"reflect"
 	"testing"
+	"time"
 )
 
 func TestToInt64E(t *testing.T) {
@@ -164,3 +169,343 @@ func TestByteToStr(t *testing.T) {
 		t.Logf("StrToByte:%v,ByteToStr:%v", strToByte, byteToStr)
 	}
 }
+
+func TestJsonKeyOrder(t *testing.T) {
+	js := `{"b":1,"a":[{"z":1,"y":{"k":[1,2]},"x":3}],"c":"{\"n\":1,\"m\":2}"}`
+	tests := []struct {
+		name    string
+		path    []interface{}
+		want    []string
+		wantErr bool
+	}{
+		{name: "root", want: []string{"b", "a", "c"}},
+		{name: "list", path: []interface{}{"a", 0}, want: []string{"z", "y", "x"}},
+		{name: "nested", path: []interface{}{"a", 0, "y"}, want: []string{"k"}},
+		{name: "json-str", path: []interface{}{"c"}, want: []string{"n", "m"}},
+		{name: "not-map", path: []interface{}{"a"}, wantErr: true},
+		{name: "not-found", path: []interface{}{"a", 1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := JsonKeyOrder(js, tt.path...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("JsonKeyOrder() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) && !tt.wantErr {
+				t.Errorf("JsonKeyOrder() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormKey(t *testing.T) {
+	tests := []struct {
+		name string
+		v1   interface{}
+		v2   interface{}
+		want bool
+	}{
+		{name: "number", v1: json.Number("1"), v2: 1, want: true},
+		{name: "float", v1: json.Number("1.50"), v2: float32(1.5), want: true},
+		{name: "uint", v1: uint8(7), v2: int64(7), want: true},
+		{name: "str-number", v1: "1", v2: 1, want: false},
+		{name: "uint64-max", v1: json.Number("18446744073709551615"), v2: uint64(18446744073709551615), want: true},
+		{name: "uint64-diff", v1: json.Number("18446744073709551615"), v2: json.Number("18446744073709551614"), want: false},
+		{name: "float-exp", v1: json.Number("1e2"), v2: 100, want: true},
+		{name: "map", v1: map[string]interface{}{"a": 1, "b": "2"}, v2: map[interface{}]interface{}{"b": "2", "a": json.Number("1")}, want: true},
+		{name: "list", v1: []interface{}{1, "x"}, v2: []int{1}, want: false},
+		{name: "nil", v1: nil, v2: "null", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormKey(tt.v1) == NormKey(tt.v2); got != tt.want {
+				t.Errorf("NormKey() %v vs %v, got %v, want %v", NormKey(tt.v1), NormKey(tt.v2), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEqualKey(t *testing.T) {
+	tests := []struct {
+		name string
+		v1   interface{}
+		v2   interface{}
+		want bool
+	}{
+		{name: "str-number", v1: "42", v2: 42, want: true},
+		{name: "json-number", v1: json.Number("1"), v2: int8(1), want: true},
+		{name: "uint64-max", v1: json.Number("18446744073709551615"), v2: uint64(18446744073709551615), want: true},
+		{name: "uint64-diff", v1: json.Number("18446744073709551615"), v2: json.Number("18446744073709551614"), want: false},
+		{name: "big-float", v1: json.Number("9223372036854775808"), v2: float64(1 << 63), want: true},
+		{name: "str-map", v1: `{"a":1}`, v2: map[string]interface{}{"a": 1}, want: false},
+		{name: "str-list", v1: "[1]", v2: []int{1}, want: false},
+		{name: "map", v1: map[string]interface{}{"a": 1}, v2: map[string]interface{}{"a": json.Number("1")}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EqualKey(tt.v1) == EqualKey(tt.v2); got != tt.want {
+				t.Errorf("EqualKey() %v vs %v, got %v, want %v", EqualKey(tt.v1), EqualKey(tt.v2), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeStructFieldErr(t *testing.T) {
+	type item struct {
+		Qty int `json:"qty"`
+	}
+	type order struct {
+		Name  string          `json:"name"`
+		Items []item          `json:"items"`
+		Owner *item           `json:"owner"`
+		Tags  map[string]bool `json:"tags"`
+	}
+	tests := []struct {
+		name  string
+		input map[string]interface{}
+		paths []string
+	}{
+		{name: "nested", input: map[string]interface{}{"items": []interface{}{map[string]interface{}{"qty": 1}, map[string]interface{}{"qty": "x"}}}, paths: []string{"items[1].qty"}},
+		{name: "quote", input: map[string]interface{}{"name": []int{1}, "tags": map[string]interface{}{"it's": "n'o"}}, paths: []string{"name", "tags[it's]"}},
+		{name: "shape", input: map[string]interface{}{"owner": "'qty'"}, paths: []string{"owner"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeStruct(tt.input, &order{}, "json")
+			sfe, ok := err.(*itferr.StructFieldErr)
+			if !ok {
+				t.Fatalf("DecodeStruct() err = %v, want *itferr.StructFieldErr", err)
+			}
+			paths := make([]string, 0, len(sfe.Fields))
+			for _, f := range sfe.Fields {
+				paths = append(paths, f.Path)
+			}
+			if !reflect.DeepEqual(paths, tt.paths) {
+				t.Errorf("DecodeStruct() paths = %v, want %v", paths, tt.paths)
+			}
+		})
+	}
+}
+
+func TestEpochToTime(t *testing.T) {
+	sec := time.Unix(1700000000, 0)
+	tests := []struct {
+		epoch int64
+		want  time.Time
+	}{
+		{1700000000, sec},
+		{1700000000123, sec.Add(123 * time.Millisecond)},
+		{1700000000123456, sec.Add(123456 * time.Microsecond)},
+		{1700000000123456789, sec.Add(123456789 * time.Nanosecond)},
+	}
+	for _, tt := range tests {
+		if got := EpochToTime(tt.epoch); !got.Equal(tt.want) {
+			t.Errorf("EpochToTime(%d) = %v, want %v", tt.epoch, got, tt.want)
+		}
+	}
+}
+
+func TestToDecimalStr(t *testing.T) {
+	tests := []struct {
+		v     interface{}
+		scale int
+		want  string
+	}{
+		{json.Number("1.005"), 2, "1.01"},
+		{json.Number("-1.005"), 2, "-1.01"},
+		{json.Number("1.5e3"), 1, "1500.0"},
+		{"12345678901234567890.123456789", 3, "12345678901234567890.123"},
+		{uint64(18446744073709551615), 0, "18446744073709551615"},
+		{0.1, 20, "0.10000000000000000000"},
+	}
+	for _, tt := range tests {
+		got, err := ToDecimalStr(tt.v, tt.scale)
+		if err != nil || got != tt.want {
+			t.Errorf("ToDecimalStr(%v, %d) = %v, %v, want %v", tt.v, tt.scale, got, err, tt.want)
+		}
+	}
+
+	for _, v := range []interface{}{"abc", "0x10", "1e99999", nil, true} {
+		if _, err := ToDecimalStr(v, 2); err == nil {
+			t.Errorf("ToDecimalStr(%v) expect error", v)
+		}
+	}
+}
+
+func TestToIntNStrict(t *testing.T) {
+	tests := []struct {
+		name    string
+		cvt     func() (interface{}, error)
+		want    interface{}
+		errCode itferr.MapItfErrorCode
+	}{
+		{"int8", func() (interface{}, error) { return ToIntN(json.Number("127"), 8, true) }, int64(127), 0},
+		{"int8-overflow", func() (interface{}, error) { return ToIntN(128, 8, true) }, nil, itferr.NumberOverflow},
+		{"int-frac", func() (interface{}, error) { return ToIntN("1.9", 0, true) }, nil, itferr.NumberPrecisionLoss},
+		{"int-sci", func() (interface{}, error) { return ToIntN(json.Number("-1.2e2"), 16, true) }, int64(-120), 0},
+		{"uint-neg", func() (interface{}, error) { return ToUintN(int8(-1), 64, true) }, nil, itferr.NumberOverflow},
+		{"uint64-max", func() (interface{}, error) { return ToUintN("18446744073709551615", 64, true) }, uint64(18446744073709551615), 0},
+		{"float32-overflow", func() (interface{}, error) { return ToFloatN(1e39, 32, true) }, nil, itferr.NumberOverflow},
+		{"float64-int", func() (interface{}, error) { return ToFloatN(int64(1)<<53+1, 64, true) }, nil, itferr.NumberPrecisionLoss},
+		{"float64-decimal", func() (interface{}, error) { return ToFloatN("0.1", 64, true) }, 0.1, 0},
+		{"lenient", func() (interface{}, error) { return ToIntN(1.9, 8, false) }, int64(1), 0},
+		{"lenient-uint64-max", func() (interface{}, error) { return ToUintN(json.Number("18446744073709551615"), 64, false) }, uint64(18446744073709551615), 0},
+		{"lenient-uint64-str", func() (interface{}, error) { return ToUintN(" 9223372036854775808", 0, false) }, uint64(9223372036854775808), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.cvt()
+			if tt.errCode != 0 {
+				if itferr.GetErrCode(err) != tt.errCode {
+					t.Errorf("err = %v, want code %v", err, tt.errCode)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("got %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestToBool(t *testing.T) {
+	tests := []struct {
+		v       interface{}
+		strict  bool
+		want    bool
+		wantErr bool
+	}{
+		{"Y", false, true, false},
+		{"off", false, false, false},
+		{" True ", false, true, false},
+		{"F", false, false, false},
+		{"1.0", false, true, false},
+		{json.Number("0"), false, false, false},
+		{2, false, false, false},
+		{-1, false, false, false},
+		{2, true, false, true},
+		{"1.0", true, true, false},
+		{"maybe", false, false, true},
+	}
+	defer func() { conf.CONF.StrictBoolCvt = false }()
+	for _, tt := range tests {
+		conf.CONF.StrictBoolCvt = tt.strict
+		got, err := ToBool(tt.v)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ToBool(%v) strict:%v = %v, %v, want %v", tt.v, tt.strict, got, err, tt.want)
+		}
+	}
+}
+
+func TestFormatFloat(t *testing.T) {
+	tests := []struct {
+		f       float64
+		bitSize int
+		nf      conf.NumberFmt
+		want    string
+	}{
+		{1234567.0, 64, conf.NumberFmt{FloatFmt: 'G'}, "1.234567E+06"},
+		{1234567.0, 64, conf.NumberFmt{FloatFmt: 'f'}, "1234567"},
+		{1e21, 64, conf.NumberFmt{FloatFmt: 'f'}, "1000000000000000000000"},
+		{1.5, 64, conf.NumberFmt{FloatFmt: 'f', Prec: conf.Prec(3)}, "1.500"},
+		{1.5, 64, conf.NumberFmt{FloatFmt: 'f', Prec: conf.Prec(3), TrimZero: true}, "1.5"},
+		{2, 64, conf.NumberFmt{FloatFmt: 'f', Prec: conf.Prec(2), TrimZero: true}, "2"},
+		{2e10, 64, conf.NumberFmt{FloatFmt: 'E', Prec: conf.Prec(3), TrimZero: true}, "2E+10"},
+		{0.1, 32, conf.NumberFmt{FloatFmt: 'f'}, "0.1"},
+	}
+	for _, tt := range tests {
+		if got := FormatFloat(tt.f, tt.bitSize, tt.nf); got != tt.want {
+			t.Errorf("FormatFloat(%v, %+v) = %v, want %v", tt.f, tt.nf, got, tt.want)
+		}
+	}
+}
+
+func TestNormNumStr(t *testing.T) {
+	tests := []struct {
+		s       string
+		literal bool
+		sep     string
+		want    string
+	}{
+		{"0x1F", true, "", "31"},
+		{"-0o17", true, "", "-15"},
+		{"0b1010", true, "", "10"},
+		{"1_000_000", true, "", "1000000"},
+		{"1__0", true, "", "1__0"},
+		{"0x1F", false, "", "0x1F"},
+		{"1,234.50", false, ",", "1234.50"},
+		{"-12,345,678", false, ",", "-12345678"},
+		{"1,23", false, ",", "1,23"},
+		{"1_000", false, ",", "1_000"},
+	}
+	defer func() { conf.CONF.ParseNumLiteral, conf.CONF.ThousandsSep = false, "" }()
+	for _, tt := range tests {
+		conf.CONF.ParseNumLiteral, conf.CONF.ThousandsSep = tt.literal, tt.sep
+		if got := normNumStr(tt.s); got != tt.want {
+			t.Errorf("normNumStr(%s) literal:%v sep:%q = %v, want %v", tt.s, tt.literal, tt.sep, got, tt.want)
+		}
+	}
+}
+
+func TestUnits(t *testing.T) {
+	sizeTests := []struct {
+		v    interface{}
+		want int64
+		code itferr.MapItfErrorCode
+	}{
+		{"512MiB", 512 << 20, 0},
+		{"10GB", 10 * 1000 * 1000 * 1000, 0},
+		{"1.5k", 1536, 0},
+		{" 100 b", 100, 0},
+		{json.Number("2048"), 2048, 0},
+		{"10XB", 0, itferr.UnitParseFailed},
+		{"-1KB", 0, itferr.UnitParseFailed},
+		{"9000000PB", 0, itferr.NumberOverflow},
+	}
+	for _, tt := range sizeTests {
+		got, err := ToBytesSize(tt.v)
+		if tt.code != 0 {
+			if itferr.GetErrCode(err) != tt.code {
+				t.Errorf("ToBytesSize(%v) err = %v, want code %v", tt.v, err, tt.code)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ToBytesSize(%v) = %v, %v, want %v", tt.v, got, err, tt.want)
+		}
+	}
+
+	durationTests := []struct {
+		v    interface{}
+		want time.Duration
+		ok   bool
+	}{
+		{"1d12h", 36 * time.Hour, true},
+		{"2w", 14 * 24 * time.Hour, true},
+		{"-1.5d", -36 * time.Hour, true},
+		{"1h30m", 90 * time.Minute, true},
+		{"1d30", 0, false},
+		{"3y", 0, false},
+		{"1D", 0, false},
+		{"200d1ns", 200*24*time.Hour + 1, true},
+		{"1.000000001d", 24*time.Hour + 86400, true},
+		{"-106751d23h47m16.854775808s", math.MinInt64, true},
+		{"2W1h", 0, false},
+	}
+	for _, tt := range durationTests {
+		got, err := ToDuration(tt.v)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("ToDuration(%v) = %v, %v, want %v", tt.v, got, err, tt.want)
+		}
+		if err != nil && itferr.GetErrCode(err) != itferr.UnitParseFailed {
+			t.Errorf("ToDuration(%v) err code = %v", tt.v, itferr.GetErrCode(err))
+		}
+	}
+	// 合计恰好为2^63纳秒时溢出
+	for _, s := range []string{"9223372036854775807ns1ns", "106751d23h47m16.854775808s", "15251w"} {
+		if got, err := ToDuration(s); itferr.GetErrCode(err) != itferr.NumberOverflow {
+			t.Errorf("ToDuration(%s) = %v, %v, want NumberOverflow", s, got, err)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package pkg
+
+import (
+	"fmt"
+	"github.com/runingriver/mapinterface/conf"
+	"reflect"
+)
+
+// customCvt 按conf.CONF中注册的转换函数将v转换,ok为false表示未注册;
+// 先按v的类型查找,v为指针时再按其指向的类型查找,dstKind未注册时依次尝试同类的宽类型(如:Int32->Int64,Uint->Uint64->Int64),
+// 浮点数最后回退到Int64(Float32->Float64->Int64)
+func customCvt(v interface{}, dstKind reflect.Kind) (result interface{}, ok bool, err error) {
+	if v == nil || !conf.CONF.HasConverters() {
+		return nil, false, nil
+	}
+	srcTypes := []reflect.Type{reflect.TypeOf(v)}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		srcTypes = append(srcTypes, rv.Elem().Type())
+	}
+
+	for _, srcType := range srcTypes {
+		for _, kind := range widerKinds(dstKind) {
+			fn, found := conf.CONF.LookupConverter(srcType, kind)
+			if !found {
+				continue
+			}
+			arg := v
+			if srcType != srcTypes[0] {
+				arg = reflect.ValueOf(v).Elem().Interface()
+			}
+			if result, err = fn(arg); err != nil {
+				return nil, true, fmt.Errorf("custom converter %v->%v: %w", srcType, kind, err)
+			}
+			return result, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+func widerKinds(kind reflect.Kind) []reflect.Kind {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		return []reflect.Kind{kind, reflect.Int64}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uintptr:
+		return []reflect.Kind{kind, reflect.Uint64, reflect.Int64}
+	case reflect.Uint64:
+		return []reflect.Kind{kind, reflect.Int64}
+	case reflect.Float32:
+		return []reflect.Kind{kind, reflect.Float64, reflect.Int64}
+	case reflect.Float64:
+		return []reflect.Kind{kind, reflect.Int64}
+	}
+	return []reflect.Kind{kind}
+}
+
+// numKind bitSize对应的数值类型,bitSize为0时表示int/uint
+func numKind(bitSize int, signed bool) reflect.Kind {
+	switch bitSize {
+	case 0:
+		if signed {
+			return reflect.Int
+		}
+		return reflect.Uint
+	case 8:
+		if signed {
+			return reflect.Int8
+		}
+		return reflect.Uint8
+	case 16:
+		if signed {
+			return reflect.Int16
+		}
+		return reflect.Uint16
+	case 32:
+		if signed {
+			return reflect.Int32
+		}
+		return reflect.Uint32
+	}
+	if signed {
+		return reflect.Int64
+	}
+	return reflect.Uint64
+}
+
+func floatKind(bitSize int) reflect.Kind {
+	if bitSize == 32 {
+		return reflect.Float32
+	}
+	return reflect.Float64
+}
+
+// customNumber 数值类转换(ToBigRat,ToBytesSize等)使用的自定义转换函数,依次查找Float64,Int64,String
+func customNumber(v interface{}) (interface{}, bool, error) {
+	if cv, ok, err := customCvt(v, reflect.Float64); ok {
+		return cv, ok, err
+	}
+	return customCvt(v, reflect.String)
+}
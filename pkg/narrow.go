@@ -0,0 +1,186 @@
+package pkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/runingriver/mapinterface/itferr"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ToIntN 将v转换为bitSize位的有符号整数(结果以int64返回,由调用方强转),bitSize为0时表示int
+// strict为false时同ToInt64;为true时超出bitSize的范围返回NumberOverflow,小数部分不为0返回NumberPrecisionLoss
+func ToIntN(v interface{}, bitSize int, strict bool) (int64, error) {
+	if cv, ok, err := customCvt(v, numKind(bitSize, true)); ok {
+		if err != nil {
+			return 0, itferr.NewBaseTypeConvErr("ToIntN", "", err)
+		}
+		v = cv
+	}
+	if !strict {
+		return ToInt64(v)
+	}
+	if bitSize == 0 {
+		bitSize = strconv.IntSize
+	}
+	locate := fmt.Sprintf("ToInt%d", bitSize)
+
+	var i *big.Int
+	switch rv := reflect.ValueOf(Interpret(v)); rv.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int, reflect.Int64:
+		i = big.NewInt(rv.Int())
+	case reflect.Uint8, reflect.Uint16, reflect.Uint, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		i = new(big.Int).SetUint64(rv.Uint())
+	case reflect.Bool:
+		return ToInt64(v)
+	default:
+		var err error
+		if i, err = exactInt(v, locate); err != nil {
+			return 0, err
+		}
+	}
+
+	minV := new(big.Int).Lsh(big.NewInt(-1), uint(bitSize-1))
+	maxV := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(bitSize-1)), big.NewInt(1))
+	if i.Cmp(minV) < 0 || i.Cmp(maxV) > 0 {
+		return 0, itferr.NewNumberOverflowErr(locate, fmt.Sprintf("%s out of int%d range", i, bitSize))
+	}
+	return i.Int64(), nil
+}
+
+// ToUintN 将v转换为bitSize位的无符号整数(结果以uint64返回,由调用方强转),bitSize为0时表示uint
+// strict为false时同uint64(ToInt64(v)),但超出int64范围的uint64文本(如:json.Number("18446744073709551615"))按uint64解析;
+// 为true时负数及超出bitSize的范围返回NumberOverflow,小数部分不为0返回NumberPrecisionLoss
+func ToUintN(v interface{}, bitSize int, strict bool) (uint64, error) {
+	if cv, ok, err := customCvt(v, numKind(bitSize, false)); ok {
+		if err != nil {
+			return 0, itferr.NewBaseTypeConvErr("ToUintN", "", err)
+		}
+		v = cv
+	}
+	if !strict {
+		if str, ok := uintText(v); ok {
+			if u, err := strconv.ParseUint(normNumStr(str), 10, 64); err == nil {
+				return u, nil
+			}
+		}
+		i, err := ToInt64(v)
+		return uint64(i), err
+	}
+	if bitSize == 0 {
+		bitSize = strconv.IntSize
+	}
+	locate := fmt.Sprintf("ToUint%d", bitSize)
+
+	var i *big.Int
+	switch rv := reflect.ValueOf(Interpret(v)); rv.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int, reflect.Int64:
+		i = big.NewInt(rv.Int())
+	case reflect.Uint8, reflect.Uint16, reflect.Uint, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		i = new(big.Int).SetUint64(rv.Uint())
+	case reflect.Bool:
+		u, err := ToInt64(v)
+		return uint64(u), err
+	default:
+		var err error
+		if i, err = exactInt(v, locate); err != nil {
+			return 0, err
+		}
+	}
+
+	maxV := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(bitSize)), big.NewInt(1))
+	if i.Sign() < 0 || i.Cmp(maxV) > 0 {
+		return 0, itferr.NewNumberOverflowErr(locate, fmt.Sprintf("%s out of uint%d range", i, bitSize))
+	}
+	return i.Uint64(), nil
+}
+
+// uintText 可能超出int64范围的数字文本
+func uintText(v interface{}) (string, bool) {
+	switch vv := Interpret(v).(type) {
+	case string:
+		return strings.TrimSpace(vv), true
+	case json.Number:
+		return string(vv), true
+	case []byte:
+		return strings.TrimSpace(string(vv)), true
+	}
+	return "", false
+}
+
+// ToFloatN 将v转换为bitSize(32或64)位的浮点数,结果以float64返回
+// strict为false时同ToFloat64;为true时超出float32/float64的范围返回NumberOverflow,
+// 整数无法被精确表示(如:大于2^53的int64转float64)时返回NumberPrecisionLoss,十进制小数本身的二进制误差不视为精度丢失
+func ToFloatN(v interface{}, bitSize int, strict bool) (float64, error) {
+	if cv, ok, err := customCvt(v, floatKind(bitSize)); ok {
+		if err != nil {
+			return 0, itferr.NewBaseTypeConvErr("ToFloatN", "", err)
+		}
+		v = cv
+	}
+	if !strict {
+		f, err := ToFloat64(v)
+		if err == nil && bitSize == 32 {
+			f = float64(float32(f))
+		}
+		return f, err
+	}
+	if bitSize != 32 {
+		bitSize = 64
+	}
+	locate := fmt.Sprintf("ToFloat%d", bitSize)
+
+	v = Interpret(v)
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Float32:
+		return rv.Float(), nil
+	case reflect.Float64:
+		f := rv.Float()
+		if bitSize == 32 && !math.IsInf(f, 0) && !math.IsNaN(f) && math.Abs(f) > math.MaxFloat32 {
+			return 0, itferr.NewNumberOverflowErr(locate, fmt.Sprintf("%v out of float32 range", f))
+		}
+		if bitSize == 32 {
+			return float64(float32(f)), nil
+		}
+		return f, nil
+	case reflect.Bool:
+		return ToFloat64(v)
+	}
+
+	r, err := ToBigRat(v)
+	if err != nil {
+		return 0, itferr.NewBaseTypeConvErr(locate, "", err)
+	}
+	var (
+		f     float64
+		exact bool
+	)
+	if bitSize == 32 {
+		f32, e := r.Float32()
+		f, exact = float64(f32), e
+	} else {
+		f, exact = r.Float64()
+	}
+	if math.IsInf(f, 0) {
+		return 0, itferr.NewNumberOverflowErr(locate, fmt.Sprintf("%s out of float%d range", r.FloatString(0), bitSize))
+	}
+	if !exact && r.IsInt() {
+		return 0, itferr.NewNumberPrecisionLossErr(locate, fmt.Sprintf("%s cannot be exactly represented by float%d", r.FloatString(0), bitSize))
+	}
+	return f, nil
+}
+
+// exactInt 基于ToBigRat精确解析v,小数部分不为0时返回NumberPrecisionLoss
+func exactInt(v interface{}, locate string) (*big.Int, error) {
+	r, err := ToBigRat(v)
+	if err != nil {
+		return nil, itferr.NewBaseTypeConvErr(locate, "", err)
+	}
+	if !r.IsInt() {
+		return nil, itferr.NewNumberPrecisionLossErr(locate, fmt.Sprintf("%v has fractional part", v))
+	}
+	return new(big.Int).Set(r.Num()), nil
+}
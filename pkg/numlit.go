@@ -0,0 +1,74 @@
+package pkg
+
+import (
+	"github.com/runingriver/mapinterface/conf"
+	"math/big"
+	"strings"
+)
+
+// normNumStr 按conf.CONF.ThousandsSep去掉千分位分隔符,conf.CONF.ParseNumLiteral为true时将0x,0o,0b前缀及含下划线的数字转为十进制文本;
+// 不符合规则时原样返回,由后续的解析报错
+func normNumStr(s string) string {
+	if sep := conf.CONF.ThousandsSep; sep != "" && strings.Contains(s, sep) {
+		if stripped, ok := stripThousandsSep(s, sep); ok {
+			s = stripped
+		}
+	}
+	if conf.CONF.ParseNumLiteral {
+		if lit, ok := numLiteral(s); ok {
+			s = lit
+		}
+	}
+	return s
+}
+
+// numLiteral 解析Go风格的数字字面量,如:"0x1F"->"31","-0b1010"->"-10","1_000.5"->"1000.5"
+func numLiteral(s string) (string, bool) {
+	body := strings.TrimLeft(s, "+-")
+	if len(body) > 2 && body[0] == '0' && strings.ContainsRune("xXoObB", rune(body[1])) {
+		if i, ok := new(big.Int).SetString(s, 0); ok {
+			return i.String(), true
+		}
+		return "", false
+	}
+	if !strings.Contains(s, "_") {
+		return "", false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] == '_' && (i == 0 || i == len(s)-1 || !isDigit(s[i-1]) || !isDigit(s[i+1])) {
+			return "", false
+		}
+	}
+	return strings.ReplaceAll(s, "_", ""), true
+}
+
+// stripThousandsSep 去掉整数部分的千分位分隔符,首组为1-3位数字,其余每组必须为3位数字,如:"-1,234,567.50"->"-1234567.50"
+func stripThousandsSep(s, sep string) (string, bool) {
+	sign, intPart, frac := "", s, ""
+	if len(intPart) > 0 && (intPart[0] == '-' || intPart[0] == '+') {
+		sign, intPart = intPart[:1], intPart[1:]
+	}
+	if i := strings.IndexAny(intPart, ".eE"); i >= 0 {
+		intPart, frac = intPart[:i], intPart[i:]
+	}
+	if strings.Contains(frac, sep) {
+		return "", false
+	}
+
+	groups := strings.Split(intPart, sep)
+	for i, g := range groups {
+		if (i == 0 && (len(g) == 0 || len(g) > 3)) || (i > 0 && len(g) != 3) {
+			return "", false
+		}
+		for j := 0; j < len(g); j++ {
+			if !isDigit(g[j]) {
+				return "", false
+			}
+		}
+	}
+	return sign + strings.Join(groups, "") + frac, true
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
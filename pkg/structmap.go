@@ -0,0 +1,331 @@
+package pkg
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"unsafe"
+)
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// StructMapOpt StructToMap的配置,零值即为默认配置
+type StructMapOpt struct {
+	TagName string // 字段名使用的tag,默认:json
+	Deep    bool   // 是否递归将嵌套的struct(含slice,array,map中的struct)也转换为map[string]interface{}
+}
+
+// IsStruct v是否为可按map处理的struct或struct指针,实现了json.Marshaler/encoding.TextMarshaler的类型(如:time.Time)除外
+func IsStruct(v interface{}) bool {
+	if v == nil {
+		return false
+	}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() || isMarshaler(rv.Type()) {
+			return false
+		}
+		rv = rv.Elem()
+	}
+	return rv.Kind() == reflect.Struct && !isMarshaler(rv.Type())
+}
+
+func isMarshaler(t reflect.Type) bool {
+	if t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType) {
+		return true
+	}
+	if t.Kind() != reflect.Ptr {
+		pt := reflect.PtrTo(t)
+		return pt.Implements(jsonMarshalerType) || pt.Implements(textMarshalerType)
+	}
+	return false
+}
+
+// StructToMap 将struct(或其指针)转换为map[string]interface{},字段名规则同encoding/json:
+// 使用tag中的名称,"-"忽略该字段,omitempty时忽略空值,未指定tag名称的匿名(嵌入)struct的字段提升到当前层级,
+// 同名字段浅层优先,同层级时仅有一个带tag名称的字段胜出,否则都忽略; Deep模式下遇到循环引用返回错误
+func StructToMap(v interface{}, opt ...StructMapOpt) (map[string]interface{}, error) {
+	o := StructMapOpt{}
+	if len(opt) > 0 {
+		o = opt[0]
+	}
+	if o.TagName == "" {
+		o.TagName = "json"
+	}
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, errors.New("param v must be struct or struct ptr")
+	}
+	e := &structEncoder{opt: o}
+	if o.Deep {
+		e.visiting = make(map[visitKey]struct{})
+	}
+	return e.structToMap(rv)
+}
+
+// structEncoder 保存一次StructToMap的配置和递归状态
+type structEncoder struct {
+	opt      StructMapOpt
+	visiting map[visitKey]struct{} // Deep模式下当前递归路径上的指针,map,slice,用于检测循环引用
+}
+
+type visitKey struct {
+	ptr uintptr
+	len int
+	typ reflect.Type
+}
+
+// structField 字段名解析的候选字段,val无效表示字段所在的嵌入指针为nil
+type structField struct {
+	name      string
+	level     int
+	tagged    bool
+	omitEmpty bool
+	val       reflect.Value
+}
+
+func (e *structEncoder) structToMap(rv reflect.Value) (map[string]interface{}, error) {
+	if !rv.CanAddr() {
+		// 复制为可寻址的值,便于读取未导出的嵌入struct中提升的字段
+		addrV := reflect.New(rv.Type()).Elem()
+		addrV.Set(rv)
+		rv = addrV
+	}
+	fields := make([]structField, 0, rv.NumField())
+	fields = e.collectFields(rv, true, 0, map[reflect.Type]bool{rv.Type(): true}, fields)
+
+	result := make(map[string]interface{}, len(fields))
+	for _, f := range dominantFields(fields) {
+		if !f.val.IsValid() || (f.omitEmpty && isEmptyValue(f.val)) {
+			continue
+		}
+		val := f.val.Interface()
+		if e.opt.Deep {
+			var err error
+			if val, err = e.deepToMap(f.val); err != nil {
+				return nil, err
+			}
+		}
+		result[f.name] = val
+	}
+	return result, nil
+}
+
+// collectFields 收集rv的字段(含嵌入struct提升的字段),valid为false时只按类型收集,用于nil的嵌入指针参与同名字段的判断;
+// onPath记录当前嵌入路径上的struct类型,避免自身嵌入时无限递归
+func (e *structEncoder) collectFields(rv reflect.Value, valid bool, level int, onPath map[reflect.Type]bool, fields []structField) []structField {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		name, omitEmpty, skip := parseFieldTag(field, e.opt.TagName)
+		if skip {
+			continue
+		}
+		fv := rv.Field(i)
+
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				if onPath[ft] {
+					continue
+				}
+				embedValid := valid
+				if fv.Kind() == reflect.Ptr {
+					if !valid || fv.IsNil() {
+						embedValid = false
+						fv = reflect.Zero(ft)
+					} else {
+						fv = fv.Elem()
+					}
+				}
+				if embedValid && !fv.CanInterface() && fv.CanAddr() {
+					fv = reflect.NewAt(fv.Type(), unsafe.Pointer(fv.UnsafeAddr())).Elem()
+				}
+				onPath[ft] = true
+				fields = e.collectFields(fv, embedValid, level+1, onPath, fields)
+				delete(onPath, ft)
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			continue // 未导出的字段
+		}
+		f := structField{name: name, level: level, tagged: name != "", omitEmpty: omitEmpty}
+		if f.name == "" {
+			f.name = field.Name
+		}
+		if valid {
+			f.val = fv
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+// dominantFields 同名字段的取舍,同encoding/json:层级最浅的胜出,同层级有多个时仅有一个带tag名称的胜出,否则都忽略
+func dominantFields(fields []structField) []structField {
+	byName := make(map[string][]structField, len(fields))
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if _, ok := byName[f.name]; !ok {
+			names = append(names, f.name)
+		}
+		byName[f.name] = append(byName[f.name], f)
+	}
+
+	result := make([]structField, 0, len(names))
+	for _, name := range names {
+		candidates := byName[name]
+		minLevel := candidates[0].level
+		for _, f := range candidates[1:] {
+			if f.level < minLevel {
+				minLevel = f.level
+			}
+		}
+		var winner, tagged []structField
+		for _, f := range candidates {
+			if f.level != minLevel {
+				continue
+			}
+			winner = append(winner, f)
+			if f.tagged {
+				tagged = append(tagged, f)
+			}
+		}
+		if len(winner) == 1 {
+			result = append(result, winner[0])
+		} else if len(tagged) == 1 {
+			result = append(result, tagged[0])
+		}
+	}
+	return result
+}
+
+// parseFieldTag 解析字段的tag,返回字段名(tag未指定时为空),是否omitempty,是否忽略该字段
+func parseFieldTag(field reflect.StructField, tagName string) (string, bool, bool) {
+	tag := field.Tag.Get(tagName)
+	if tag == "-" {
+		return "", false, true
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	omitEmpty := false
+	for _, opt := range strings.Split(opts, ",") {
+		if opt == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return name, omitEmpty, false
+}
+
+// deepToMap 递归转换rv中的struct,slice/array转换为[]interface{},map转换为map[string]interface{}(key经ToStr)
+func (e *structEncoder) deepToMap(rv reflect.Value) (interface{}, error) {
+	if !rv.IsValid() {
+		return nil, nil
+	}
+	if isMarshaler(rv.Type()) {
+		return rv.Interface(), nil
+	}
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return rv.Interface(), nil
+		}
+		if elem := rv.Elem(); elem.Kind() == reflect.Struct || elem.Kind() == reflect.Slice || elem.Kind() == reflect.Array ||
+			elem.Kind() == reflect.Map || elem.Kind() == reflect.Ptr || elem.Kind() == reflect.Interface {
+			if rv.Kind() == reflect.Interface {
+				return e.deepToMap(elem)
+			}
+			leave, err := e.enter(rv, 0)
+			if err != nil {
+				return nil, err
+			}
+			defer leave()
+			return e.deepToMap(elem)
+		}
+		return rv.Interface(), nil
+	case reflect.Struct:
+		return e.structToMap(rv)
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && (rv.IsNil() || rv.Type().Elem().Kind() == reflect.Uint8) {
+			return rv.Interface(), nil
+		}
+		if rv.Kind() == reflect.Slice {
+			leave, err := e.enter(rv, rv.Len())
+			if err != nil {
+				return nil, err
+			}
+			defer leave()
+		}
+		list := make([]interface{}, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			item, err := e.deepToMap(rv.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, item)
+		}
+		return list, nil
+	case reflect.Map:
+		if rv.IsNil() {
+			return rv.Interface(), nil
+		}
+		leave, err := e.enter(rv, 0)
+		if err != nil {
+			return nil, err
+		}
+		defer leave()
+		m := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			item, err := e.deepToMap(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			m[ToStr(iter.Key().Interface())] = item
+		}
+		return m, nil
+	}
+	return rv.Interface(), nil
+}
+
+// enter 将指针,map,slice加入当前递归路径,已在路径上时说明存在循环引用
+func (e *structEncoder) enter(rv reflect.Value, n int) (func(), error) {
+	key := visitKey{ptr: rv.Pointer(), len: n, typ: rv.Type()}
+	if _, ok := e.visiting[key]; ok {
+		return nil, fmt.Errorf("encountered a cycle via %s", rv.Type())
+	}
+	e.visiting[key] = struct{}{}
+	return func() { delete(e.visiting, key) }, nil
+}
+
+// isEmptyValue omitempty的判断规则,同encoding/json
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
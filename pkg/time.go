@@ -0,0 +1,138 @@
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// DefaultTimeLayouts ToTime未指定layouts时依次尝试的时间格式
+var DefaultTimeLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006/01/02 15:04:05",
+	"2006-01-02",
+	time.RFC1123Z,
+	time.RFC1123,
+}
+
+// ToTime 将v转换为time.Time,支持time.Time,unix时间戳(数字或数字字符串,含json.Number)以及按layouts解析的字符串
+// 时间戳的精度(秒,毫秒,微秒,纳秒)按数值大小推断,见EpochToTime;layouts为空时使用DefaultTimeLayouts;loc为nil时使用time.Local
+func ToTime(v interface{}, loc *time.Location, layouts ...string) (time.Time, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+	if cv, ok, err := customCvt(v, reflect.Struct); ok {
+		if err != nil {
+			return time.Time{}, err
+		}
+		v = cv
+	}
+	v = Interpret(v)
+	if t, ok := v.(time.Time); ok {
+		return t.In(loc), nil
+	}
+	if v == nil {
+		return time.Time{}, errors.New("nil cannot convert to time")
+	}
+
+	if isNumber(v) {
+		epoch, err := ToInt64(v)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return EpochToTime(epoch).In(loc), nil
+	}
+
+	// 指定了layouts时优先按layouts解析,以支持"20060102"这类纯数字的格式
+	s := strings.TrimSpace(ToStr(v))
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	if epoch, err := ToInt64(s); err == nil && isNumStr(s) {
+		return EpochToTime(epoch).In(loc), nil
+	}
+	if len(layouts) == 0 {
+		for _, layout := range DefaultTimeLayouts {
+			if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+				return t, nil
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf("%s not match time layouts", s)
+}
+
+// EpochToTime 按数值大小推断unix时间戳的精度:小于1e11为秒,小于1e14为毫秒,小于1e17为微秒,否则为纳秒
+// 即秒级时间戳可表示到5138年,毫秒级最早可表示到1973年
+func EpochToTime(epoch int64) time.Time {
+	abs := epoch
+	if abs < 0 {
+		abs = -abs
+	}
+	switch {
+	case abs < 1e11:
+		return time.Unix(epoch, 0)
+	case abs < 1e14:
+		return time.UnixMilli(epoch)
+	case abs < 1e17:
+		return time.UnixMicro(epoch)
+	}
+	return time.Unix(0, epoch)
+}
+
+// ToDuration 将v转换为time.Duration,字符串按time.ParseDuration解析(如"1h30m"),另支持d(天),w(周)(如"1d12h"),单位仅支持小写,数字或数字字符串按秒处理(可为小数)
+func ToDuration(v interface{}) (time.Duration, error) {
+	if cv, ok, err := customCvt(v, reflect.Int64); ok {
+		if err != nil {
+			return 0, err
+		}
+		v = cv
+	}
+	v = Interpret(v)
+	if d, ok := v.(time.Duration); ok {
+		return d, nil
+	}
+	if v == nil {
+		return 0, errors.New("nil cannot convert to duration")
+	}
+
+	s := strings.TrimSpace(ToStr(v))
+	if isNumber(v) || isNumStr(s) {
+		seconds, err := ToFloat64(v)
+		if err != nil {
+			return 0, err
+		}
+		if math.Abs(seconds) > math.MaxInt64/float64(time.Second) {
+			return 0, fmt.Errorf("%v seconds overflow duration", v)
+		}
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+	return parseDuration(s)
+}
+
+// isNumStr s是否为十进制数字字符串,如:"-12","1.5"
+func isNumStr(s string) bool {
+	if s == "" {
+		return false
+	}
+	dot := false
+	for i, c := range s {
+		switch {
+		case c >= '0' && c <= '9':
+		case c == '-' && i == 0 && len(s) > 1:
+		case c == '.' && !dot:
+			dot = true
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,268 @@
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"github.com/runingriver/mapinterface/conf"
+	"math/big"
+	"reflect"
+	"time"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+	bigIntType   = reflect.TypeOf(big.Int{})
+	bigFloatType = reflect.TypeOf(big.Float{})
+	bigRatType   = reflect.TypeOf(big.Rat{})
+)
+
+// ConvPathErr slice/array/map的元素转换失败时返回的错误,Path为失败元素的路径,如:[scores][1]
+type ConvPathErr struct {
+	Path string
+	Err  error
+}
+
+func (e *ConvPathErr) Error() string {
+	return fmt.Sprintf("%s %v", e.Path, e.Err)
+}
+
+func (e *ConvPathErr) Unwrap() error {
+	return e.Err
+}
+
+// withConvPath 在err的路径前追加一级路径seg
+func withConvPath(seg string, err error) error {
+	if pathErr, ok := err.(*ConvPathErr); ok {
+		return &ConvPathErr{Path: seg + pathErr.Path, Err: pathErr.Err}
+	}
+	return &ConvPathErr{Path: seg, Err: err}
+}
+
+// ConvertTo 将v转换为typ类型,基础类型沿用ToStr,ToInt64,ToFloat64,ToBool的转换规则
+// 支持基础类型,由支持的类型组成的slice,array,map(可嵌套),struct(来源为map或json str)以及它们的指针;
+// slice/map的元素转换失败时,按conf中的SkipCvtFailForToArrayType/SkipCvtFailForToMapType决定跳过或返回错误;
+// 数值按目标类型的位数转换,conf.CONF.StrictNumberCvt为true时溢出或丢失精度返回错误,见ToIntN,ToUintN,ToFloatN
+func ConvertTo(v interface{}, typ reflect.Type) (reflect.Value, error) {
+	return ConvertToX(v, typ, conf.CONF.StrictNumberCvt)
+}
+
+// ConvertToX 同ConvertTo,strict指定数值转换是否使用严格模式,通常为conf.IsStrictNumberCvt(ctx)
+func ConvertToX(v interface{}, typ reflect.Type, strict bool) (reflect.Value, error) {
+	if v != nil && reflect.TypeOf(v).AssignableTo(typ) {
+		return reflect.ValueOf(v), nil
+	}
+	if cv, ok, err := customCvt(v, typ.Kind()); ok {
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if cv != nil && reflect.TypeOf(cv) == reflect.TypeOf(v) {
+			return reflect.Value{}, fmt.Errorf("custom converter of %T returned same type", v)
+		}
+		return ConvertToX(cv, typ, strict)
+	}
+	if typ.Kind() == reflect.Interface {
+		if v == nil {
+			return reflect.Zero(typ), nil
+		}
+		return reflect.Value{}, fmt.Errorf("%T not implement %v", v, typ)
+	}
+
+	v = Interpret(v)
+	if v == nil {
+		switch typ.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Map:
+			return reflect.Zero(typ), nil
+		}
+	}
+	if v != nil && reflect.TypeOf(v).AssignableTo(typ) {
+		return reflect.ValueOf(v), nil
+	}
+
+	result := reflect.New(typ).Elem()
+	switch typ {
+	case timeType:
+		t, err := ToTime(v, nil)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(t), nil
+	case durationType:
+		d, err := ToDuration(v)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(d), nil
+	case bigIntType:
+		i, err := ToBigInt(v)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(i).Elem(), nil
+	case bigFloatType:
+		f, err := ToBigFloat(v)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(f).Elem(), nil
+	case bigRatType:
+		r, err := ToBigRat(v)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(r).Elem(), nil
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		result.SetString(ToStr(v))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := ToIntN(v, typ.Bits(), strict)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		result.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		i, err := ToUintN(v, typ.Bits(), strict)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		result.SetUint(i)
+	case reflect.Float32, reflect.Float64:
+		f, err := ToFloatN(v, typ.Bits(), strict)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		result.SetFloat(f)
+	case reflect.Bool:
+		bl, err := ToBool(v)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		result.SetBool(bl)
+	case reflect.Ptr:
+		elem, err := ConvertToX(v, typ.Elem(), strict)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		result = reflect.New(typ.Elem())
+		result.Elem().Set(elem)
+	case reflect.Slice, reflect.Array:
+		return convertToList(v, typ, strict)
+	case reflect.Map:
+		return convertToMap(v, typ, strict)
+	case reflect.Struct:
+		if isJson, js := JsonChecker(v); isJson {
+			if _, err := JsonLoadsObj(js, result.Addr().Interface()); err != nil {
+				return reflect.Value{}, err
+			}
+			return result, nil
+		}
+		if reflect.ValueOf(v).Kind() != reflect.Map {
+			return reflect.Value{}, fmt.Errorf("%T cannot convert to %v", v, typ)
+		}
+		if _, err := MapToStruct(v, result.Addr().Interface()); err != nil {
+			return reflect.Value{}, err
+		}
+	default:
+		return reflect.Value{}, fmt.Errorf("un-support convert to %v", typ)
+	}
+	return result, nil
+}
+
+func convertToList(v interface{}, typ reflect.Type, strict bool) (reflect.Value, error) {
+	if s, ok := v.(string); ok && typ.Elem().Kind() == reflect.Uint8 && typ.Kind() == reflect.Slice {
+		if isJson, _ := JsonChecker(s); !isJson {
+			return reflect.ValueOf(StrToByte(s)).Convert(typ), nil
+		}
+	}
+	if isJson, js := JsonChecker(v); isJson {
+		listObj, err := JsonLoadsList(js)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v = listObj
+	}
+
+	rfV := reflect.ValueOf(v)
+	if rfV.Kind() != reflect.Slice && rfV.Kind() != reflect.Array {
+		return reflect.Value{}, fmt.Errorf("%T cannot convert to %v", v, typ)
+	}
+
+	elems := make([]reflect.Value, 0, rfV.Len())
+	for i := 0; i < rfV.Len(); i++ {
+		var elem reflect.Value
+		err := errors.New("cannot interface")
+		if idxV := rfV.Index(i); idxV.CanInterface() {
+			elem, err = ConvertToX(idxV.Interface(), typ.Elem(), strict)
+		}
+		if err != nil {
+			err = withConvPath(fmt.Sprintf("[%d]", i), err)
+		}
+		if err != nil {
+			if conf.CONF.SkipCvtFailForToArrayType {
+				continue
+			}
+			return reflect.Value{}, err
+		}
+		elems = append(elems, elem)
+	}
+
+	if typ.Kind() == reflect.Array {
+		if len(elems) > typ.Len() {
+			return reflect.Value{}, fmt.Errorf("list len %d exceed %v", len(elems), typ)
+		}
+		result := reflect.New(typ).Elem()
+		for i, elem := range elems {
+			result.Index(i).Set(elem)
+		}
+		return result, nil
+	}
+	result := reflect.MakeSlice(typ, 0, len(elems))
+	return reflect.Append(result, elems...), nil
+}
+
+func convertToMap(v interface{}, typ reflect.Type, strict bool) (reflect.Value, error) {
+	if isJson, js := JsonChecker(v); isJson {
+		mapObj, err := JsonLoadsMap(js)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v = mapObj
+	}
+	if IsStruct(v) {
+		mapObj, err := StructToMap(v)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v = mapObj
+	}
+
+	rfV := reflect.ValueOf(v)
+	if rfV.Kind() != reflect.Map {
+		return reflect.Value{}, fmt.Errorf("%T cannot convert to %v", v, typ)
+	}
+
+	result := reflect.MakeMapWithSize(typ, rfV.Len())
+	iter := rfV.MapRange()
+	for iter.Next() {
+		var key, val reflect.Value
+		err := errors.New("cannot interface")
+		if iter.Key().CanInterface() && iter.Value().CanInterface() {
+			if key, err = ConvertToX(iter.Key().Interface(), typ.Key(), strict); err == nil {
+				val, err = ConvertToX(iter.Value().Interface(), typ.Elem(), strict)
+			}
+		}
+		if err != nil {
+			err = withConvPath(fmt.Sprintf("[%v]", iter.Key()), err)
+		}
+		if err != nil {
+			if conf.CONF.SkipCvtFailForToMapType {
+				continue
+			}
+			return reflect.Value{}, err
+		}
+		result.SetMapIndex(key, val)
+	}
+	return result, nil
+}
@@ -0,0 +1,174 @@
+package pkg
+
+import (
+	"fmt"
+	"github.com/runingriver/mapinterface/itferr"
+	"math"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	sizeRegex     = regexp.MustCompile(`^([+-]?[0-9]+(?:\.[0-9]+)?)\s*([a-zA-Z]*)$`)
+	durationRegex = regexp.MustCompile(`([0-9]*\.?[0-9]+)([a-zA-Zµμ]*)`)
+
+	// sizeUnits 大小写不敏感;KB,MB等为1000进制,KiB,MiB等为1024进制,K,M等同KiB,MiB
+	sizeUnits = map[string]int64{
+		"": 1, "b": 1,
+		"k": 1 << 10, "kb": 1000, "kib": 1 << 10,
+		"m": 1 << 20, "mb": 1000 * 1000, "mib": 1 << 20,
+		"g": 1 << 30, "gb": 1000 * 1000 * 1000, "gib": 1 << 30,
+		"t": 1 << 40, "tb": 1000 * 1000 * 1000 * 1000, "tib": 1 << 40,
+		"p": 1 << 50, "pb": 1000 * 1000 * 1000 * 1000 * 1000, "pib": 1 << 50,
+	}
+	// durationUnits time.ParseDuration支持的单位及扩展的d(天),w(周)
+	durationUnits = map[string]time.Duration{
+		"ns": time.Nanosecond,
+		"us": time.Microsecond, "µs": time.Microsecond, "μs": time.Microsecond,
+		"ms": time.Millisecond,
+		"s":  time.Second,
+		"m":  time.Minute,
+		"h":  time.Hour,
+		"d":  24 * time.Hour,
+		"w":  7 * 24 * time.Hour,
+	}
+)
+
+// ToBytesSize 将v转换为字节数,如:"512MiB","10GB","1.5k";数字及不带单位的数字字符串按字节处理,小数部分舍去,负数返回错误
+// 单位大小写不敏感,KB,MB,GB,TB,PB为1000进制,KiB,MiB,GiB,TiB,PiB及K,M,G,T,P为1024进制
+func ToBytesSize(v interface{}) (int64, error) {
+	if cv, ok, err := customNumber(v); ok {
+		if err != nil {
+			return 0, itferr.NewMapItfErr("ToBytesSize", itferr.UnitParseFailed, "custom converter failed", err)
+		}
+		v = cv
+	}
+	v = Interpret(v)
+	if v == nil {
+		return 0, itferr.NewUnitParseErr("ToBytesSize", "nil cannot convert to bytes size")
+	}
+
+	s := strings.TrimSpace(ToStr(v))
+	var r *big.Rat
+	if isNumber(v) {
+		var err error
+		if r, err = ToBigRat(v); err != nil {
+			return 0, itferr.NewMapItfErr("ToBytesSize", itferr.UnitParseFailed, fmt.Sprintf("%q is not a size", s), err)
+		}
+	} else {
+		m := sizeRegex.FindStringSubmatch(s)
+		if m == nil {
+			return 0, itferr.NewUnitParseErr("ToBytesSize", fmt.Sprintf("%q is not a size, eg: 512MiB,10GB", s))
+		}
+		unit, ok := sizeUnits[strings.ToLower(m[2])]
+		if !ok {
+			return 0, itferr.NewUnitParseErr("ToBytesSize", fmt.Sprintf("unknown size unit %q in %q", m[2], s))
+		}
+		r, _ = new(big.Rat).SetString(m[1])
+		r.Mul(r, new(big.Rat).SetInt64(unit))
+	}
+
+	if r.Sign() < 0 {
+		return 0, itferr.NewUnitParseErr("ToBytesSize", fmt.Sprintf("%q is negative", s))
+	}
+	size := new(big.Int).Quo(r.Num(), r.Denom())
+	if !size.IsInt64() {
+		return 0, itferr.NewNumberOverflowErr("ToBytesSize", fmt.Sprintf("%q out of int64 range", s))
+	}
+	return size.Int64(), nil
+}
+
+// ToPercent 将百分数转换为比例,如:"75%"转为0.75;不带%的数字或数字字符串视为比例本身,如:0.75
+func ToPercent(v interface{}) (float64, error) {
+	if cv, ok, err := customNumber(v); ok {
+		if err != nil {
+			return 0, itferr.NewMapItfErr("ToPercent", itferr.UnitParseFailed, "custom converter failed", err)
+		}
+		v = cv
+	}
+	v = Interpret(v)
+	if v == nil {
+		return 0, itferr.NewUnitParseErr("ToPercent", "nil cannot convert to percent")
+	}
+	if isNumber(v) {
+		return ToFloat64(v)
+	}
+
+	s := strings.TrimSpace(ToStr(v))
+	numStr := strings.TrimSpace(strings.TrimSuffix(s, "%"))
+	f, err := ToFloat64(numStr)
+	if err != nil {
+		return 0, itferr.NewMapItfErr("ToPercent", itferr.UnitParseFailed, fmt.Sprintf("%q is not a percent, eg: 75%%", s), err)
+	}
+	if numStr != s {
+		f /= 100
+	}
+	return f, nil
+}
+
+// parseDuration 在time.ParseDuration的基础上支持d(天),w(周),如:"1d12h","2w","-1.5d";同time.ParseDuration单位仅支持小写
+func parseDuration(s string) (time.Duration, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return d, nil
+	}
+
+	body, neg := s, false
+	if body != "" && (body[0] == '-' || body[0] == '+') {
+		body, neg = body[1:], body[0] == '-'
+	}
+	if body == "" {
+		return 0, itferr.NewUnitParseErr("ToDuration", "empty duration")
+	}
+
+	// 按整数纳秒累加,负数时可以取到math.MinInt64
+	var total uint64
+	limit := uint64(math.MaxInt64)
+	if neg {
+		limit++
+	}
+	end := 0
+	for _, loc := range durationRegex.FindAllStringSubmatchIndex(body, -1) {
+		if loc[0] != end {
+			break
+		}
+		end = loc[1]
+		num, unit := body[loc[2]:loc[3]], body[loc[4]:loc[5]]
+		if unit == "" {
+			return 0, itferr.NewUnitParseErr("ToDuration", fmt.Sprintf("missing unit after %s in %q", num, s))
+		}
+		if unit != strings.ToLower(unit) {
+			return 0, itferr.NewUnitParseErr("ToDuration", fmt.Sprintf("unit %q in %q must be lower-case", unit, s))
+		}
+		seg, err := durationSegment(num, unit)
+		if err != nil {
+			return 0, itferr.NewMapItfErr("ToDuration", itferr.UnitParseFailed, fmt.Sprintf("invalid duration %q", s), err)
+		}
+		if !seg.IsUint64() || seg.Uint64() > limit-total {
+			return 0, itferr.NewNumberOverflowErr("ToDuration", fmt.Sprintf("%q overflow duration", s))
+		}
+		total += seg.Uint64()
+	}
+	if end != len(body) {
+		return 0, itferr.NewUnitParseErr("ToDuration", fmt.Sprintf("invalid duration %q, eg: 1h30m,1d12h,2w", s))
+	}
+	if neg {
+		return time.Duration(-total), nil
+	}
+	return time.Duration(total), nil
+}
+
+// durationSegment 单个数值及单位对应的纳秒数,按精确的十进制计算,不足1纳秒的部分舍去
+func durationSegment(num, unit string) (*big.Int, error) {
+	d, ok := durationUnits[unit]
+	if !ok {
+		return nil, fmt.Errorf("unknown unit %q", unit)
+	}
+	r, ok := new(big.Rat).SetString(num)
+	if !ok {
+		return nil, fmt.Errorf("invalid number %q", num)
+	}
+	ns := new(big.Int).Mul(r.Num(), big.NewInt(int64(d)))
+	return ns.Quo(ns, r.Denom()), nil
+}